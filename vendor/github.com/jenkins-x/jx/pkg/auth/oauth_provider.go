@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+)
+
+// OAuthProvider is a Provider that exchanges a stored OAuth2 refresh token for a short-lived
+// access token against the given identity provider, refreshing it each time Token is called.
+// Only the refresh token needs to be persisted in an AuthConfig; the access token it yields is
+// never written to disk, only into the short-lived Secret a caller creates from it.
+type OAuthProvider struct {
+	config       *oauth2.Config
+	refreshToken string
+}
+
+// NewOAuthProvider creates a Provider backed by an OAuth2 authorization-code/device-code flow,
+// given the client credentials and token endpoint of the identity provider plus a previously
+// obtained refresh token
+func NewOAuthProvider(clientID, clientSecret, authURL, tokenURL, refreshToken string) *OAuthProvider {
+	return &OAuthProvider{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  authURL,
+				TokenURL: tokenURL,
+			},
+		},
+		refreshToken: refreshToken,
+	}
+}
+
+// Token exchanges the refresh token for a fresh access token
+func (p *OAuthProvider) Token() (string, error) {
+	tokenSource := p.config.TokenSource(context.Background(), &oauth2.Token{RefreshToken: p.refreshToken})
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,25 @@
+package auth
+
+// Provider resolves a short-lived access token for a server on demand. It lets callers
+// that need to present a token (e.g. for a pipeline credential Secret) stay agnostic of how
+// that token is obtained, whether it's a static API token or one backed by an OAuth2 flow.
+type Provider interface {
+	// Token returns a valid access token, refreshing it first if required
+	Token() (string, error)
+}
+
+// StaticTokenProvider is a Provider backed by a fixed, never refreshed token such as a
+// long-lived personal access token
+type StaticTokenProvider struct {
+	AccessToken string
+}
+
+// NewStaticTokenProvider creates a Provider that always returns the given token
+func NewStaticTokenProvider(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{AccessToken: token}
+}
+
+// Token returns the static access token
+func (p *StaticTokenProvider) Token() (string, error) {
+	return p.AccessToken, nil
+}
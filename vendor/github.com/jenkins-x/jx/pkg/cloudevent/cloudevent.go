@@ -0,0 +1,145 @@
+// Package cloudevent publishes and queries CloudEvents v1.0 payloads so that CVE scan results can
+// be consumed by event-driven tooling (Tekton, Argo, Knative) instead of having those consumers
+// poll Anchore (or another CVE scanner) directly
+package cloudevent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// EnvSinkURL is the environment variable consulted for the events sink URL when --events-sink (or
+// an equivalent flag) is not passed
+const EnvSinkURL = "JX_CVE_EVENTS_SINK"
+
+// CVEScanCompletedType is the CloudEvents "type" published once a CVE scan finishes
+const CVEScanCompletedType = "io.jenkins-x.cve.scan.completed"
+
+// Event is a CloudEvents v1.0 envelope. Only the attributes jx populates are modelled; unknown
+// attributes a sink adds (e.g. "time" stamped by a broker) round-trip fine since Data is untyped.
+type Event struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            string      `json:"time,omitempty"`
+	DataContentType string      `json:"datacontenttype,omitempty"`
+	Data            interface{} `json:"data,omitempty"`
+}
+
+// ScanCompletedData is the payload of a CVEScanCompletedType event: a summary of the scan rather
+// than the full vulnerability table, so consumers can gate on the verdict without re-fetching it
+type ScanCompletedData struct {
+	Image               string         `json:"image"`
+	Digest              string         `json:"digest"`
+	Provider            string         `json:"provider"`
+	PolicyStatus        string         `json:"policyStatus"`
+	VulnerabilityCounts map[string]int `json:"vulnerabilityCounts"`
+}
+
+// NewScanCompletedEvent builds the CloudEvent published when a CVE scan of image finishes,
+// identifying the event by digest so 'jx step wait cve-event' can correlate the two
+func NewScanCompletedEvent(source string, digest string, data ScanCompletedData) Event {
+	return Event{
+		SpecVersion:     "1.0",
+		ID:              fmt.Sprintf("%s-%d", digest, time.Now().UnixNano()),
+		Source:          source,
+		Type:            CVEScanCompletedType,
+		Subject:         digest,
+		Time:            time.Now().UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// ScanCompletedData decodes e.Data into a ScanCompletedData, for callers like
+// 'jx step wait cve-event' that need to gate on the scan verdict rather than just its arrival.
+// e.Data comes back from QueryBySubject as a generic map[string]interface{}, so it's round-tripped
+// through JSON to land in the typed struct.
+func (e Event) ScanCompletedData() (ScanCompletedData, error) {
+	var data ScanCompletedData
+	raw, err := json.Marshal(e.Data)
+	if err != nil {
+		return data, err
+	}
+	err = json.Unmarshal(raw, &data)
+	return data, err
+}
+
+// SinkURL returns flagValue if set, otherwise falls back to the EnvSinkURL environment variable
+func SinkURL(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv(EnvSinkURL)
+}
+
+// Publish POSTs event to sinkURL as JSON, retrying with an exponential backoff (starting at 1s,
+// capped at 30s) for up to 1 minute if the sink is unreachable or returns a 5xx
+func Publish(client *http.Client, sinkURL string, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	publish := func() error {
+		req, err := http.NewRequest("POST", sinkURL, bytes.NewReader(body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("events sink %s returned status %d", sinkURL, resp.StatusCode)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return backoff.Permanent(fmt.Errorf("events sink %s returned status %d", sinkURL, resp.StatusCode))
+		}
+		return nil
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = time.Minute
+
+	return backoff.Retry(publish, b)
+}
+
+// QueryBySubject GETs sinkURL for the events recorded for subject (e.g. an image digest), for use
+// by 'jx step wait cve-event' to poll for a matching event. The sink is expected to support a
+// "subject" query parameter and respond with a JSON array of Event.
+func QueryBySubject(client *http.Client, sinkURL string, subject string) ([]Event, error) {
+	req, err := http.NewRequest("GET", sinkURL+"?subject="+url.QueryEscape(subject), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("events sink %s returned status %d", sinkURL, resp.StatusCode)
+	}
+
+	events := []Event{}
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to decode events from %s: %v", sinkURL, err)
+	}
+	return events, nil
+}
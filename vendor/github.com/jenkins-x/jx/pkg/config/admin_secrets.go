@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"io/ioutil"
 
 	"strings"
 
@@ -97,6 +98,43 @@ type AdminSecretsConfig struct {
 	Jenkins          *Jenkins         `yaml:"jenkins,omitempty"`
 	Nexus            *Nexus           `yaml:"nexus,omitempty"`
 	PipelineSecrets  *PipelineSecrets `yaml:"PipelineSecrets,omitempty"`
+	OIDC             *OIDCConfig      `yaml:"oidc,omitempty"`
+}
+
+// OIDCConfig holds the settings needed to enable OIDC/SSO login for the Jenkins X platform
+// services (Jenkins, ChartMuseum, Grafana) and, via dex/oauth2-proxy, the ingress-controller
+type OIDCConfig struct {
+	IssuerURL    string   `yaml:"issuerURL,omitempty"`
+	ClientID     string   `yaml:"clientID,omitempty"`
+	ClientSecret string   `yaml:"clientSecret,omitempty"`
+	RedirectURL  string   `yaml:"redirectURL,omitempty"`
+	GroupsClaim  string   `yaml:"groupsClaim,omitempty"`
+	AdminGroups  []string `yaml:"adminGroups,omitempty"`
+}
+
+// Enabled returns true if enough information has been configured to turn on OIDC
+func (o *OIDCConfig) Enabled() bool {
+	return o != nil && o.IssuerURL != ""
+}
+
+// HelmValues returns the helm --set values needed to wire this OIDC configuration into the
+// Jenkins, ChartMuseum and Grafana charts plus the ingress-controller's dex/oauth2-proxy sidecar
+func (c *AdminSecretsConfig) HelmValues() []string {
+	if !c.OIDC.Enabled() {
+		return nil
+	}
+	o := c.OIDC
+	return []string{
+		"jenkins.oidc.issuerUrl=" + o.IssuerURL,
+		"jenkins.oidc.clientId=" + o.ClientID,
+		"jenkins.oidc.clientSecret=" + o.ClientSecret,
+		"chartmuseum.oidc.issuerUrl=" + o.IssuerURL,
+		"chartmuseum.oidc.clientId=" + o.ClientID,
+		"grafana.auth.generic_oauth.enabled=true",
+		"grafana.auth.generic_oauth.auth_url=" + o.IssuerURL,
+		"ingress.oauth2Proxy.enabled=true",
+		"ingress.oauth2Proxy.oidcIssuerUrl=" + o.IssuerURL,
+	}
 }
 
 type Nexus struct {
@@ -111,6 +149,13 @@ type AdminSecretsService struct {
 
 type AdminSecretsFlags struct {
 	DefaultAdminPassword string
+
+	OIDCIssuerURL    string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	OIDCGroupsClaim  string
+	OIDCAdminGroups  []string
 }
 
 func (s *AdminSecretsService) AddAdminSecretsValues(cmd *cobra.Command) {
@@ -121,6 +166,12 @@ func (s *AdminSecretsService) AddAdminSecretsValues(cmd *cobra.Command) {
 		s.Flags.DefaultAdminPassword = strings.ToLower(randomdata.SillyName())
 	}
 
+	cmd.Flags().StringVarP(&s.Flags.OIDCIssuerURL, "oidc-issuer", "", "", "the OIDC issuer URL used to enable single-sign-on instead of basic auth for Jenkins, ChartMuseum and Grafana")
+	cmd.Flags().StringVarP(&s.Flags.OIDCClientID, "oidc-client-id", "", "", "the OIDC client ID")
+	cmd.Flags().StringVarP(&s.Flags.OIDCClientSecret, "oidc-client-secret", "", "", "the OIDC client secret")
+	cmd.Flags().StringVarP(&s.Flags.OIDCRedirectURL, "oidc-redirect-url", "", "", "the OIDC redirect URL callback")
+	cmd.Flags().StringVarP(&s.Flags.OIDCGroupsClaim, "oidc-groups-claim", "", "groups", "the OIDC claim used to look up the user's groups")
+	cmd.Flags().StringArrayVarP(&s.Flags.OIDCAdminGroups, "oidc-admin-group", "", nil, "an OIDC group which should be granted admin access. Can be specified multiple times")
 }
 
 func (c AdminSecretsConfig) String() (string, error) {
@@ -150,6 +201,36 @@ func (s *AdminSecretsService) NewAdminSecretsConfig() error {
 	hash := hashSha(s.Flags.DefaultAdminPassword)
 
 	s.Secrets.IngressBasicAuth = fmt.Sprintf("admin:{SHA}%s", hash)
+
+	// keep the existing basic-auth defaults untouched when OIDC is not configured
+	if s.Flags.OIDCIssuerURL != "" {
+		s.Secrets.OIDC = &OIDCConfig{
+			IssuerURL:    s.Flags.OIDCIssuerURL,
+			ClientID:     s.Flags.OIDCClientID,
+			ClientSecret: s.Flags.OIDCClientSecret,
+			RedirectURL:  s.Flags.OIDCRedirectURL,
+			GroupsClaim:  s.Flags.OIDCGroupsClaim,
+			AdminGroups:  s.Flags.OIDCAdminGroups,
+		}
+	}
+	return nil
+}
+
+// LoadConfig loads the AdminSecretsConfig from the service's FileName on disk
+func (s *AdminSecretsService) LoadConfig() error {
+	if s.FileName == "" {
+		return fmt.Errorf("no admin secrets file name configured")
+	}
+	data, err := ioutil.ReadFile(s.FileName)
+	if err != nil {
+		return fmt.Errorf("failed to read admin secrets file %s: %v", s.FileName, err)
+	}
+	config := AdminSecretsConfig{}
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal admin secrets file %s: %v", s.FileName, err)
+	}
+	s.Secrets = config
 	return nil
 }
 
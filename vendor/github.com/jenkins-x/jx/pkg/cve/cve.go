@@ -0,0 +1,52 @@
+package cve
+
+import (
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
+	"k8s.io/client-go/kubernetes"
+
+	jxclient "github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+)
+
+// CVEQuery the query used to find vulnerabilities for an image
+type CVEQuery struct {
+	ImageID         string
+	ImageName       string
+	Environment     string
+	TargetNamespace string
+	Vesion          string
+}
+
+// CVEProvider is implemented by the various CVE scanning backends (Anchore, Trivy, Grype, ...)
+// so that `jx get cve` can be served from whichever provider a team has installed
+type CVEProvider interface {
+	// Authenticate verifies the provider's credentials are valid and the service is reachable
+	Authenticate() error
+
+	// SupportsQuery returns true if this provider is able to answer the given query
+	SupportsQuery(query CVEQuery) bool
+
+	// GetImageVulnerabilityTable populates the given table with the vulnerabilities found for the query
+	GetImageVulnerabilityTable(jxClient jxclient.Interface, kubeClient kubernetes.Interface, table *util.Table, query CVEQuery) error
+}
+
+// NewProvider creates the CVEProvider for the given kind (anchore, trivy, grype)
+func NewProvider(kind string, server *auth.AuthServer, authToken *auth.UserAuth) (CVEProvider, error) {
+	switch kind {
+	case "", KindAnchore:
+		return NewAnchoreProvider(server, authToken)
+	case KindTrivy:
+		return NewTrivyProvider(server, authToken)
+	case KindGrype:
+		return NewGrypeProvider(server, authToken)
+	default:
+		return nil, util.InvalidOption("provider", kind, []string{KindAnchore, KindTrivy, KindGrype})
+	}
+}
+
+// the supported CVE provider kinds
+const (
+	KindAnchore = "anchore"
+	KindTrivy   = "trivy"
+	KindGrype   = "grype"
+)
@@ -0,0 +1,51 @@
+package cve
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
+	"k8s.io/client-go/kubernetes"
+
+	jxclient "github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+)
+
+// TrivyServiceName is the name of the in-cluster service exposed by the trivy-operator addon
+const TrivyServiceName = "trivy-operator"
+
+// TrivyProvider queries a trivy-operator service running in the cluster
+type TrivyProvider struct {
+	Server *auth.AuthServer
+	Auth   *auth.UserAuth
+}
+
+// NewTrivyProvider creates a new provider backed by Trivy
+func NewTrivyProvider(server *auth.AuthServer, authToken *auth.UserAuth) (CVEProvider, error) {
+	if server == nil || server.URL == "" {
+		return nil, fmt.Errorf("no Trivy server configured")
+	}
+	return &TrivyProvider{
+		Server: server,
+		Auth:   authToken,
+	}, nil
+}
+
+// Authenticate verifies we can talk to the trivy-operator service
+func (p *TrivyProvider) Authenticate() error {
+	if p.Server.URL == "" {
+		return fmt.Errorf("no Trivy server URL configured")
+	}
+	return nil
+}
+
+// SupportsQuery Trivy can scan any image by name or ID but has no notion of an environment
+func (p *TrivyProvider) SupportsQuery(query CVEQuery) bool {
+	return query.ImageName != "" || query.ImageID != ""
+}
+
+// GetImageVulnerabilityTable is not yet implemented for this provider. It deliberately errors
+// rather than returning nil with an empty table, since a caller treating that as "no
+// vulnerabilities found" would be misled into believing a scan it never ran came back clean.
+func (p *TrivyProvider) GetImageVulnerabilityTable(jxClient jxclient.Interface, kubeClient kubernetes.Interface, table *util.Table, query CVEQuery) error {
+	return fmt.Errorf("querying trivy-operator's VulnerabilityReport CRDs via 'jx get cve' is not yet implemented")
+}
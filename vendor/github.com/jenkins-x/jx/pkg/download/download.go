@@ -0,0 +1,205 @@
+package download
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// MirrorEnvVar, if set, is used in place of github.com and storage.googleapis.com when
+// downloading installer assets, letting an internal artifact mirror front those hosts without
+// every installer having to know about it - the download equivalent of setting GOPROXY
+const MirrorEnvVar = "JX_DOWNLOAD_MIRROR"
+
+// mirroredHosts are the upstream hosts rewritten to MirrorEnvVar when it's set
+var mirroredHosts = []string{"github.com", "storage.googleapis.com"}
+
+// maxAttempts is the total number of attempts, the first one included, that File makes on a
+// transient network or 5xx failure before giving up
+const maxAttempts = 5
+
+// File downloads rawURL to destPath, retrying transient network errors and 5xx responses with
+// exponential backoff, resuming a previously interrupted download via an HTTP Range request, and
+// short-circuiting via the ~/.jx/cache/<checksum> cache when checksum is already known (typically
+// because the version stream pinned it) so repeat installs of the same version are instant
+func File(rawURL string, destPath string, checksum string) error {
+	if checksum != "" {
+		cached, err := cachePath(checksum)
+		if err == nil {
+			if exists, _ := util.FileExists(cached); exists {
+				return copyFile(cached, destPath)
+			}
+		}
+	}
+
+	resolvedURL := mirror(rawURL)
+	tmpPath := destPath + ".tmp"
+	err := fetchWithRetry(resolvedURL, tmpPath)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmpPath, destPath)
+	if err != nil {
+		return err
+	}
+
+	if checksum != "" {
+		cached, err := cachePath(checksum)
+		if err == nil {
+			// best effort - a failure to populate the cache shouldn't fail the download
+			copyFile(destPath, cached)
+		}
+	}
+	return nil
+}
+
+// mirror rewrites rawURL onto MirrorEnvVar if it points at one of mirroredHosts and the env var
+// is set, otherwise it returns rawURL unchanged
+func mirror(rawURL string) string {
+	mirrorBase := os.Getenv(MirrorEnvVar)
+	if mirrorBase == "" {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	for _, host := range mirroredHosts {
+		if u.Host == host {
+			return strings.TrimSuffix(mirrorBase, "/") + u.Path
+		}
+	}
+	return rawURL
+}
+
+// statusError wraps a non-2xx HTTP response so fetchWithRetry can tell a retryable 5xx from a
+// permanent 4xx
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %s", http.StatusText(e.statusCode))
+}
+
+func fetchWithRetry(url string, tmpPath string) error {
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt))
+		}
+		lastErr = fetchOnce(url, tmpPath)
+		if lastErr == nil {
+			return nil
+		}
+		if statusErr, ok := lastErr.(*statusError); ok && statusErr.statusCode < 500 {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("failed to download %s after %d attempts: %s", url, maxAttempts, lastErr)
+}
+
+// backoff is the exponential delay of 2^n seconds before attempt n, so the retries after the
+// first attempt wait 2s, 4s, 8s, 16s, ...
+func backoff(attempt int) time.Duration {
+	return time.Duration(1<<uint(attempt)) * time.Second
+}
+
+// fetchOnce downloads url into tmpPath, resuming from tmpPath's current size via an HTTP Range
+// request if it already exists from a previous interrupted attempt
+func fetchOnce(rawURL string, tmpPath string) error {
+	var resumeFrom int64
+	if info, err := os.Stat(tmpPath); err == nil {
+		resumeFrom = info.Size()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	if resumeFrom > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(resumeFrom, 10)+"-")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the server doesn't know the range we resumed from any more - start over
+		os.Remove(tmpPath)
+		return fetchOnce(rawURL, tmpPath)
+	default:
+		return &statusError{statusCode: resp.StatusCode}
+	}
+
+	f, err := os.OpenFile(tmpPath, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+// cacheDir returns ~/.jx/cache, creating it if necessary
+func cacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".jx", "cache")
+	err = os.MkdirAll(dir, 0760)
+	if err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// sha256HexPattern matches a well-formed lowercase or uppercase hex SHA256 digest. checksum is
+// used as a path segment under ~/.jx/cache, so it must be validated before use - it ultimately
+// comes from a version stream YAML entry, and a malformed or malicious value (e.g. containing
+// "../") could otherwise be used to read or write outside the cache directory.
+var sha256HexPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+func cachePath(checksum string) (string, error) {
+	if !sha256HexPattern.MatchString(checksum) {
+		return "", fmt.Errorf("invalid SHA256 checksum %q: expected 64 hex characters", checksum)
+	}
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, checksum), nil
+}
+
+func copyFile(src string, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,147 @@
+package draft
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// defaultPacksByExtension maps a detected source file extension to the jx template pack
+// that should be used for a project whose dominant language is written using it
+var defaultPacksByExtension = map[string]string{
+	".java": "java",
+	".go":   "go",
+	".js":   "node",
+	".ts":   "node",
+	".py":   "python",
+	".rb":   "ruby",
+}
+
+// ignoredDirs are skipped when counting source bytes so that vendored or generated code
+// doesn't skew the language detection
+var ignoredDirs = map[string]bool{
+	".git":         true,
+	"vendor":       true,
+	"node_modules": true,
+	"target":       true,
+	"charts":       true,
+}
+
+// packsConfig is the shape of the optional $JX_HOME/packs.yaml override file
+type packsConfig struct {
+	Packs map[string]string `yaml:"packs"`
+}
+
+// DoPackDetection ranks the languages found under dir by byte count and returns the name of
+// the template pack that best matches the dominant language. home is the $JX_HOME directory
+// used to look up user overrides in packs.yaml; out receives progress messages. An empty
+// string is returned, with no error, when no known language is detected so that callers can
+// fall back to Draft's own detection
+func DoPackDetection(home string, out io.Writer, dir string) (string, error) {
+	bytesByExtension, err := countBytesByExtension(dir)
+	if err != nil {
+		return "", err
+	}
+
+	packsByExtension, err := loadPacksByExtension(home)
+	if err != nil {
+		return "", err
+	}
+
+	extension := dominantExtension(bytesByExtension, packsByExtension)
+	if extension == "" {
+		return "", nil
+	}
+
+	pack := packsByExtension[extension]
+	if out != nil {
+		fmt.Fprintf(out, "Detected %s source, using the %s template pack\n", extension, pack)
+	}
+	return pack, nil
+}
+
+// countBytesByExtension walks dir and totals up the byte size of every regular file, keyed by
+// its lower-case extension
+func countBytesByExtension(dir string) (map[string]int64, error) {
+	totals := map[string]int64{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if ignoredDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext == "" {
+			return nil
+		}
+		totals[ext] += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// loadPacksByExtension merges the built-in extension->pack table with any overrides found in
+// $JX_HOME/packs.yaml
+func loadPacksByExtension(home string) (map[string]string, error) {
+	packs := map[string]string{}
+	for ext, pack := range defaultPacksByExtension {
+		packs[ext] = pack
+	}
+
+	if home == "" {
+		return packs, nil
+	}
+	path := filepath.Join(home, "packs.yaml")
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return packs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config := packsConfig{}
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, err
+	}
+	for ext, pack := range config.Packs {
+		packs[ext] = pack
+	}
+	return packs, nil
+}
+
+// dominantExtension returns the known extension with the highest byte count, breaking ties
+// alphabetically so results are deterministic
+func dominantExtension(bytesByExtension map[string]int64, packsByExtension map[string]string) string {
+	candidates := []string{}
+	for ext := range bytesByExtension {
+		if _, known := packsByExtension[ext]; known {
+			candidates = append(candidates, ext)
+		}
+	}
+	sort.Strings(candidates)
+
+	best := ""
+	var bestSize int64
+	for _, ext := range candidates {
+		if bytesByExtension[ext] > bestSize {
+			best = ext
+			bestSize = bytesByExtension[ext]
+		}
+	}
+	return best
+}
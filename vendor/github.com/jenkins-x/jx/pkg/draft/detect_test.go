@@ -0,0 +1,27 @@
+package draft
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoPackDetection(t *testing.T) {
+	tests := []struct {
+		fixture string
+		pack    string
+	}{
+		{"testdata/java", "java"},
+		{"testdata/go", "go"},
+		{"testdata/node", "node"},
+		{"testdata/python", "python"},
+		{"testdata/unknown", ""},
+	}
+
+	for _, test := range tests {
+		pack, err := DoPackDetection("", ioutil.Discard, test.fixture)
+		assert.NoError(t, err, "DoPackDetection(%s)", test.fixture)
+		assert.Equal(t, test.pack, pack, "pack detected for %s", test.fixture)
+	}
+}
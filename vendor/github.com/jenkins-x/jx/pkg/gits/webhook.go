@@ -0,0 +1,29 @@
+package gits
+
+// WebHookRegistrar is implemented by git providers that can list and reconcile webhooks,
+// allowing callers to make webhook registration idempotent instead of always creating a new hook
+type WebHookRegistrar interface {
+	ListWebHooks(owner string, repo string) ([]*GitWebHookArguments, error)
+	CreateWebHook(webhook *GitWebHookArguments) error
+	UpdateWebHook(webhook *GitWebHookArguments) error
+	DeleteWebHook(owner string, repo string, id string) error
+}
+
+// ReconcileWebHook ensures a webhook matching webhook.URL exists for the repo, creating it if
+// missing or updating it in place if one is already registered for that URL, instead of always
+// creating a new one. It always updates rather than skipping on an unchanged secret: list/read
+// webhook APIs (GitHub, GitLab, Gitea) never echo back the configured secret, so there's no way
+// to tell from existing whether it's actually still current.
+func ReconcileWebHook(registrar WebHookRegistrar, webhook *GitWebHookArguments) error {
+	hooks, err := registrar.ListWebHooks(webhook.Owner, webhook.Repo)
+	if err != nil {
+		return err
+	}
+	for _, existing := range hooks {
+		if existing.URL == webhook.URL {
+			webhook.ID = existing.ID
+			return registrar.UpdateWebHook(webhook)
+		}
+	}
+	return registrar.CreateWebHook(webhook)
+}
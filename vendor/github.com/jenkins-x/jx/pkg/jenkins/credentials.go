@@ -0,0 +1,119 @@
+package jenkins
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+)
+
+// CredentialKind identifies which kind of Jenkins Credentials Plugin credential a
+// CredentialCreateOption describes
+type CredentialKind string
+
+const (
+	// CredentialKindUsernamePassword is a plain username and password/API token credential
+	CredentialKindUsernamePassword CredentialKind = "username-password"
+	// CredentialKindSecretText is a single opaque secret string, e.g. a webhook HMAC secret
+	CredentialKindSecretText CredentialKind = "secret-text"
+	// CredentialKindSSHPrivateKey is an SSH username plus private key (and optional passphrase)
+	CredentialKindSSHPrivateKey CredentialKind = "ssh-username-private-key"
+	// CredentialKindFile is an uploaded file, e.g. a kubeconfig or a service account key
+	CredentialKindFile CredentialKind = "file"
+
+	// DefaultCredentialStore is the Jenkins credentials store nearly every install uses
+	DefaultCredentialStore = "system"
+	// DefaultCredentialDomain is the global credentials domain, valid for every job
+	DefaultCredentialDomain = "_"
+)
+
+// CredentialCreateOption describes a Jenkins credential to create or update via the
+// Credentials Plugin's XML API. Only the fields relevant to Type need to be populated; the
+// others are ignored when building the XML.
+type CredentialCreateOption struct {
+	ID          string
+	Description string
+	Type        CredentialKind
+	Store       string
+	Domain      string
+
+	Username   string
+	Password   string
+	Secret     string
+	PrivateKey string
+	Passphrase string
+	FileName   string
+	FileData   []byte
+}
+
+// storeOrDefault returns o.Store, defaulting to DefaultCredentialStore if unset
+func (o *CredentialCreateOption) storeOrDefault() string {
+	if o.Store == "" {
+		return DefaultCredentialStore
+	}
+	return o.Store
+}
+
+// domainOrDefault returns o.Domain, defaulting to DefaultCredentialDomain if unset
+func (o *CredentialCreateOption) domainOrDefault() string {
+	if o.Domain == "" {
+		return DefaultCredentialDomain
+	}
+	return o.Domain
+}
+
+// xmlEscape escapes s for safe embedding as XML character data, so a credential field containing
+// "<", "&", etc. can't break out of its element and inject or alter sibling nodes (e.g. the
+// <id>/<scope> the Jenkins API stores the credential under)
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	// xml.EscapeText never errors for a bytes.Buffer destination
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// CredentialXML renders the Jenkins Credentials Plugin XML document used to create or update
+// the credential described by option, for use with the store/createCredentials and
+// store/updateCredentials XML API endpoints
+func CredentialXML(option CredentialCreateOption) (string, error) {
+	switch option.Type {
+	case CredentialKindUsernamePassword:
+		return fmt.Sprintf(`<com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl>
+  <scope>GLOBAL</scope>
+  <id>%s</id>
+  <description>%s</description>
+  <username>%s</username>
+  <password>%s</password>
+</com.cloudbees.plugins.credentials.impl.UsernamePasswordCredentialsImpl>`, xmlEscape(option.ID), xmlEscape(option.Description), xmlEscape(option.Username), xmlEscape(option.Password)), nil
+
+	case CredentialKindSecretText:
+		return fmt.Sprintf(`<org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl>
+  <scope>GLOBAL</scope>
+  <id>%s</id>
+  <description>%s</description>
+  <secret>%s</secret>
+</org.jenkinsci.plugins.plaincredentials.impl.StringCredentialsImpl>`, xmlEscape(option.ID), xmlEscape(option.Description), xmlEscape(option.Secret)), nil
+
+	case CredentialKindSSHPrivateKey:
+		return fmt.Sprintf(`<com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey>
+  <scope>GLOBAL</scope>
+  <id>%s</id>
+  <description>%s</description>
+  <username>%s</username>
+  <privateKeySource class="com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey$DirectEntryPrivateKeySource">
+    <privateKey>%s</privateKey>
+  </privateKeySource>
+  <passphrase>%s</passphrase>
+</com.cloudbees.jenkins.plugins.sshcredentials.impl.BasicSSHUserPrivateKey>`, xmlEscape(option.ID), xmlEscape(option.Description), xmlEscape(option.Username), xmlEscape(option.PrivateKey), xmlEscape(option.Passphrase)), nil
+
+	case CredentialKindFile:
+		return fmt.Sprintf(`<org.jenkinsci.plugins.plaincredentials.impl.FileCredentialsImpl>
+  <scope>GLOBAL</scope>
+  <id>%s</id>
+  <description>%s</description>
+  <fileName>%s</fileName>
+  <secretBytes>%s</secretBytes>
+</org.jenkinsci.plugins.plaincredentials.impl.FileCredentialsImpl>`, xmlEscape(option.ID), xmlEscape(option.Description), xmlEscape(option.FileName), base64.StdEncoding.EncodeToString(option.FileData)), nil
+	}
+	return "", fmt.Errorf("unsupported Jenkins credential kind: %s", option.Type)
+}
@@ -0,0 +1,49 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialXMLUsernamePassword(t *testing.T) {
+	xml, err := CredentialXML(CredentialCreateOption{
+		ID:       "my-server",
+		Type:     CredentialKindUsernamePassword,
+		Username: "jstrachan",
+		Password: "s3cr3t",
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, xml, "<id>my-server</id>")
+	assert.Contains(t, xml, "<username>jstrachan</username>")
+	assert.Contains(t, xml, "<password>s3cr3t</password>")
+	assert.Contains(t, xml, "UsernamePasswordCredentialsImpl")
+}
+
+func TestCredentialXMLEscapesMetacharacters(t *testing.T) {
+	xml, err := CredentialXML(CredentialCreateOption{
+		ID:          "my-server",
+		Type:        CredentialKindUsernamePassword,
+		Description: `</description><scope>SYSTEM</scope><id>evil</id><description>`,
+		Username:    "jstrachan",
+		Password:    `s3cr3t & "quoted" <tag>`,
+	})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, xml, "<scope>SYSTEM</scope>")
+	assert.NotContains(t, xml, "<id>evil</id>")
+	assert.Contains(t, xml, "&lt;/description&gt;")
+	assert.Contains(t, xml, "&amp;")
+	assert.Contains(t, xml, "&lt;tag&gt;")
+	assert.Contains(t, xml, "<id>my-server</id>")
+}
+
+func TestCredentialXMLUnsupportedKind(t *testing.T) {
+	_, err := CredentialXML(CredentialCreateOption{
+		ID:   "my-server",
+		Type: CredentialKind("made-up-kind"),
+	})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// CheckHealth polls the Jenkins login page at url until it responds or timeout elapses,
+// retrying with an exponential backoff (starting at 1s, capped at 30s). HTTP 200 and 403 are
+// both treated as healthy since a locked-down Jenkins returns 403 for an anonymous GET of the
+// login page once it's actually up
+func CheckHealth(url string, timeout time.Duration) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	check := func() error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusForbidden {
+			return nil
+		}
+		return fmt.Errorf("jenkins at %s returned status %d", url, resp.StatusCode)
+	}
+
+	// backoff.Retry always makes its first attempt immediately, so the common case of an
+	// already healthy Jenkins returns with a single GET and no sleep
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = timeout
+
+	return backoff.Retry(check, b)
+}
@@ -0,0 +1,47 @@
+package jenkins
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// IsTransientError returns true if err looks like a transient Jenkins failure, e.g. a 5xx
+// response or a connection-level failure while the master is restarting, as opposed to a 4xx
+// error (a missing job, a bad credential) that retrying won't fix
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, code := range []string{"500", "502", "503", "504"} {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	for _, sub := range []string{"connection refused", "connection reset", "eof", "timeout", "no such host", "i/o timeout"} {
+		if strings.Contains(msg, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryTransient calls fn, retrying with the same exponential backoff schedule as CheckHealth
+// (starting at 1s, capped at 30s) for up to timeout if it returns a transient error. Any other
+// error is returned immediately without retrying.
+func RetryTransient(timeout time.Duration, fn func() error) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Second
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = timeout
+
+	return backoff.Retry(func() error {
+		err := fn()
+		if err != nil && !IsTransientError(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, b)
+}
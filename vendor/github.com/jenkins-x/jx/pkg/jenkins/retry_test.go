@@ -0,0 +1,34 @@
+package jenkins
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransientRetriesTransientErrors(t *testing.T) {
+	attempts := 0
+	err := RetryTransient(10*time.Second, func() error {
+		attempts++
+		if attempts <= 2 {
+			return errors.New("jenkins returned status 503")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryTransientStopsOnFatalError(t *testing.T) {
+	attempts := 0
+	err := RetryTransient(10*time.Second, func() error {
+		attempts++
+		return errors.New("jenkins returned status 404")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// BulkImportManifest is the `--from-file` manifest format for importing many repos in one go
+type BulkImportManifest struct {
+	Repos []BulkImportRepo `yaml:"repos"`
+}
+
+// BulkImportRepo describes a single repo entry in a BulkImportManifest
+type BulkImportRepo struct {
+	GitURL         string `yaml:"gitURL"`
+	BranchPattern  string `yaml:"branchPattern"`
+	Jenkinsfile    string `yaml:"jenkinsfile"`
+	CredentialsRef string `yaml:"credentialsRef"`
+}
+
+// BulkImportResult is the outcome of importing a single BulkImportRepo
+type BulkImportResult struct {
+	GitURL string
+	Error  error
+}
+
+// LoadBulkImportManifest reads and parses the `--from-file` manifest at path
+func LoadBulkImportManifest(path string) ([]BulkImportRepo, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read bulk import manifest %s: %s", path, err)
+	}
+	manifest := BulkImportManifest{}
+	err = yaml.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse bulk import manifest %s: %s", path, err)
+	}
+	return manifest.Repos, nil
+}
+
+// BulkImport imports every repo in repos concurrently, bounded by maxConcurrent, strictly
+// honoring batch mode so a missing credential fails that repo fast instead of prompting, and
+// returns one BulkImportResult per repo rather than stopping at the first failure
+func (o *ImportOptions) BulkImport(repos []BulkImportRepo, maxConcurrent int) []BulkImportResult {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 4
+	}
+	if maxConcurrent > len(repos) {
+		maxConcurrent = len(repos)
+	}
+
+	results := make([]BulkImportResult, len(repos))
+	sem := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, repo BulkImportRepo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = BulkImportResult{GitURL: repo.GitURL, Error: o.bulkImportRepo(repo)}
+		}(i, repo)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// bulkImportRepo imports a single BulkImportRepo, always in batch mode so a missing
+// credentialsRef or any other input that would normally prompt fails fast instead
+func (o *ImportOptions) bulkImportRepo(repo BulkImportRepo) error {
+	if repo.CredentialsRef == "" {
+		return fmt.Errorf("credentialsRef is required for %s when importing from a manifest", repo.GitURL)
+	}
+	o2 := ImportOptions{
+		CommonOptions:           o.CommonOptions,
+		RepoURL:                 repo.GitURL,
+		BranchPattern:           repo.BranchPattern,
+		Jenkinsfile:             repo.Jenkinsfile,
+		Credentials:             repo.CredentialsRef,
+		Jenkins:                 o.Jenkins,
+		JenkinsSelector:         o.JenkinsSelector,
+		JenkinsHealthTimeout:    o.JenkinsHealthTimeout,
+		JenkinsRetryTimeout:     o.JenkinsRetryTimeout,
+		WebhookEngine:           o.WebhookEngine,
+		DisableJenkinsfileCheck: o.DisableJenkinsfileCheck,
+		DisableDraft:            o.DisableDraft,
+	}
+	o2.BatchMode = true
+	return o2.Run()
+}
+
+// ImportFromFile reads the manifest at o.FromFile and bulk imports every repo it lists,
+// printing a per-repo report and returning an aggregate error if any repo failed
+func (o *ImportOptions) ImportFromFile() error {
+	repos, err := LoadBulkImportManifest(o.FromFile)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("No repos found in manifest %s", o.FromFile)
+	}
+
+	results := o.BulkImport(repos, o.MaxConcurrentImports)
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			o.Printf("%s %s: %s\n", util.ColorError("FAILED"), result.GitURL, result.Error)
+		} else {
+			o.Printf("%s %s\n", util.ColorInfo("imported"), result.GitURL)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repos failed to import", failed, len(results))
+	}
+	return nil
+}
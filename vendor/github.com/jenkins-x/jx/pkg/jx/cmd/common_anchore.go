@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// anchorePolicyUpload is the subset of the POST /v1/policies response we care about
+type anchorePolicyUpload struct {
+	PolicyID string `json:"policyId"`
+}
+
+// anchoreRequest makes an authenticated JSON request against the Anchore Engine API rooted at
+// serverURL, decoding the response body into out if it's non-nil. Shared by the scan and addon
+// commands so both talk to Anchore Engine the same way
+func anchoreRequest(client *http.Client, serverURL string, username string, password string, method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(serverURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("anchore engine returned status %d from %s: %s", resp.StatusCode, path, string(data))
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// uploadAnchorePolicyBundle reads the policy bundle JSON at bundleFile, POSTs it to
+// /v1/policies and returns the ID Anchore Engine assigned it
+func uploadAnchorePolicyBundle(client *http.Client, serverURL string, username string, password string, bundleFile string) (string, error) {
+	data, err := ioutil.ReadFile(bundleFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read policy bundle %s: %v", bundleFile, err)
+	}
+	var bundle map[string]interface{}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return "", fmt.Errorf("%s is not a valid Anchore policy bundle: %v", bundleFile, err)
+	}
+
+	uploads := []anchorePolicyUpload{}
+	err = anchoreRequest(client, serverURL, username, password, "POST", "/v1/policies", bundle, &uploads)
+	if err != nil {
+		return "", err
+	}
+	if len(uploads) == 0 || uploads[0].PolicyID == "" {
+		return "", fmt.Errorf("anchore engine did not return a policy ID for %s", bundleFile)
+	}
+	return uploads[0].PolicyID, nil
+}
+
+// activateAnchorePolicy marks policyID as the active policy bundle for future image checks
+func activateAnchorePolicy(client *http.Client, serverURL string, username string, password string, policyID string) error {
+	body := map[string]bool{"active": true}
+	return anchoreRequest(client, serverURL, username, password, "PUT", "/v1/policies/"+policyID, body, nil)
+}
+
+// applyAnchorePolicy uploads policyBundle if one was given, falls back to the already uploaded
+// policyID otherwise, activates whichever policy that resolves to and returns its ID so the
+// caller can annotate the service with it
+func applyAnchorePolicy(client *http.Client, serverURL string, username string, password string, policyBundle string, policyID string) (string, error) {
+	id := policyID
+	if policyBundle != "" {
+		uploadedID, err := uploadAnchorePolicyBundle(client, serverURL, username, password, policyBundle)
+		if err != nil {
+			return "", err
+		}
+		id = uploadedID
+	}
+	if id == "" {
+		return "", fmt.Errorf("either --policy-bundle or --policy-id must be specified")
+	}
+	err := activateAnchorePolicy(client, serverURL, username, password, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to activate Anchore policy %s: %v", id, err)
+	}
+	return id, nil
+}
+
+// annotateServiceWithAnchorePolicy records policyID on serviceName so 'jx get cve' and pipeline
+// policy gates can look up which bundle was activated without calling back into Anchore Engine
+func annotateServiceWithAnchorePolicy(kubeClient kubernetes.Interface, namespace string, serviceName string, policyID string) error {
+	svc, err := kubeClient.CoreV1().Services(namespace).Get(serviceName, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Service %s: %v", serviceName, err)
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[kube.AnnotationAnchorePolicyID] = policyID
+	_, err = kubeClient.CoreV1().Services(namespace).Update(svc)
+	if err != nil {
+		return fmt.Errorf("failed to annotate service %s/%s with policy ID %s: %v", namespace, serviceName, policyID, err)
+	}
+	return nil
+}
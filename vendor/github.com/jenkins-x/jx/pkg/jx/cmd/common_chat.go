@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+)
+
+// ChatServerHostURLKind discovers the kind of chat service (slack, mattermost, rocketchat,
+// hipchat) registered in the cluster for hostURL, the same way GitServerHostURLKind discovers
+// git server kinds. This lets team members reuse a ChatService another developer registered via
+// `jx create chat server` instead of every developer having to run it locally.
+func (o *CommonOptions) ChatServerHostURLKind(hostURL string) (string, error) {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return "", err
+	}
+
+	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	if err != nil {
+		return "", err
+	}
+	err = kube.RegisterChatServiceCRD(apisClient)
+	if err != nil {
+		return "", err
+	}
+
+	kind, err := kube.GetChatServiceKind(jxClient, devNs, hostURL)
+	if err != nil {
+		return kind, err
+	}
+	if kind == "" {
+		return "", fmt.Errorf("No chat server kind could be found for URL %s\nPlease register it via: jx create chat server <kind> %s", hostURL, hostURL)
+	}
+	return kind, nil
+}
@@ -3,8 +3,8 @@ package cmd
 import (
 	"fmt"
 	"io/ioutil"
-	"time"
 
+	"github.com/jenkins-x/golang-jenkins"
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/issues"
@@ -17,6 +17,14 @@ import (
 )
 
 // createGitProvider creates a git from the given directory
+//
+// Note: unlike updatePipelineGitCredentialsSecret, this does not resolve the server's token via
+// an auth.Provider, so a server configured with --oauth-refresh-token (see create_git_token.go)
+// is only honoured for the pipeline credential Secret, not for interactive API calls made through
+// the GitProvider returned here. Wiring that through requires the refresh token and OAuth client
+// config to be persisted against the server in AuthConfigService, which nothing currently does -
+// jx create git token only ever uses it to compute a one-off access token. Fixing this properly
+// belongs together with that persistence change, not as an isolated edit here.
 func (o *CommonOptions) createGitProvider(dir string) (*gits.GitRepositoryInfo, gits.GitProvider, issues.IssueProvider, error) {
 	gitDir, gitConfDir, err := gits.FindGitConfigDir(dir)
 	if err != nil {
@@ -51,7 +59,11 @@ func (o *CommonOptions) createGitProvider(dir string) (*gits.GitRepositoryInfo,
 	return gitInfo, gitProvider, tracker, nil
 }
 
-func (o *CommonOptions) updatePipelineGitCredentialsSecret(server *auth.AuthServer, userAuth *auth.UserAuth) (string, error) {
+func (o *CommonOptions) updatePipelineGitCredentialsSecret(server *auth.AuthServer, userAuth *auth.UserAuth, sshPrivateKeyFile string, knownHostsFile string, tokenProvider auth.Provider, async bool) (string, error) {
+	if sshPrivateKeyFile == "" && knownHostsFile != "" {
+		return "", fmt.Errorf("a known_hosts file can only be used together with an SSH private key")
+	}
+
 	client, curNs, err := o.Factory.CreateClient()
 	if err != nil {
 		return "", err
@@ -67,8 +79,14 @@ func (o *CommonOptions) updatePipelineGitCredentialsSecret(server *auth.AuthServ
 	secret, err := secrets.Get(name, options)
 	create := false
 	operation := "update"
+	authMode := kube.JenkinsGitServerAuthModeToken
+	credentialsType := kube.ValueCredentialTypeUsernamePassword
+	if sshPrivateKeyFile != "" {
+		authMode = kube.JenkinsGitServerAuthModeSSH
+		credentialsType = kube.ValueCredentialTypeSSHPrivateKey
+	}
 	labels := map[string]string{
-		kube.LabelCredentialsType: kube.ValueCredentialTypeUsernamePassword,
+		kube.LabelCredentialsType: credentialsType,
 		kube.LabelCreatedBy:       kube.ValueCreatedByJX,
 		kube.LabelKind:            kube.ValueKindGit,
 		kube.LabelServiceKind:     server.Kind,
@@ -94,11 +112,36 @@ func (o *CommonOptions) updatePipelineGitCredentialsSecret(server *auth.AuthServ
 		secret.Annotations = kube.MergeMaps(secret.Annotations, annotations)
 		secret.Labels = kube.MergeMaps(secret.Labels, labels)
 	}
-	if userAuth.Username != "" {
-		secret.Data["username"] = []byte(userAuth.Username)
-	}
-	if userAuth.ApiToken != "" {
-		secret.Data["password"] = []byte(userAuth.ApiToken)
+	if sshPrivateKeyFile != "" {
+		keyData, err := ioutil.ReadFile(sshPrivateKeyFile)
+		if err != nil {
+			return name, fmt.Errorf("Failed to read SSH private key file %s: %s", sshPrivateKeyFile, err)
+		}
+		secret.Type = v1.SecretTypeSSHAuth
+		secret.Data[v1.SSHAuthPrivateKey] = keyData
+		if knownHostsFile != "" {
+			knownHostsData, err := ioutil.ReadFile(knownHostsFile)
+			if err != nil {
+				return name, fmt.Errorf("Failed to read known_hosts file %s: %s", knownHostsFile, err)
+			}
+			secret.Data[kube.SecretDataKnownHosts] = knownHostsData
+		}
+	} else if tokenProvider != nil {
+		accessToken, err := tokenProvider.Token()
+		if err != nil {
+			return name, fmt.Errorf("Failed to obtain an access token for %s: %s", server.URL, err)
+		}
+		if userAuth.Username != "" {
+			secret.Data["username"] = []byte(userAuth.Username)
+		}
+		secret.Data["password"] = []byte(accessToken)
+	} else {
+		if userAuth.Username != "" {
+			secret.Data["username"] = []byte(userAuth.Username)
+		}
+		if userAuth.ApiToken != "" {
+			secret.Data["password"] = []byte(userAuth.ApiToken)
+		}
 	}
 	if create {
 		_, err = secrets.Create(secret)
@@ -114,7 +157,7 @@ func (o *CommonOptions) updatePipelineGitCredentialsSecret(server *auth.AuthServ
 		return name, fmt.Errorf("Could not load Jenkins ConfigMap: %s", err)
 	}
 
-	updated, err := kube.UpdateJenkinsGitServers(cm, server, userAuth, name)
+	updated, err := kube.UpdateJenkinsGitServers(cm, server, userAuth, name, authMode)
 	if err != nil {
 		return name, err
 	}
@@ -125,27 +168,52 @@ func (o *CommonOptions) updatePipelineGitCredentialsSecret(server *auth.AuthServ
 		}
 		o.Printf("Updated the Jenkins ConfigMap %s\n", kube.ConfigMapJenkinsX)
 
-		// wait a little bit to give k8s chance to sync the ConfigMap to the file system
-		time.Sleep(time.Second * 2)
-
-		// lets ensure that the git server + credential is in the Jenkins server configuration
 		jenk, err := o.JenkinsClient()
 		if err != nil {
 			return name, err
 		}
-		// TODO reload does not seem to reload the plugin content
-		//err = jenk.Reload()
-		err = jenk.SafeRestart()
+		err = o.hotReloadJenkinsGitCredentials(jenk, name, userAuth)
 		if err != nil {
-			o.warnf("Failed to safe restart Jenkins after configuration change %s\n", err)
-		} else {
-			o.Printf("Safe Restarted Jenkins server\n")
+			if async {
+				o.warnf("Failed to hot-reload Jenkins git credentials for %s: %s\nRun `jx create git server --restart` once you're done onboarding servers to pick this up\n", name, err)
+			} else {
+				o.warnf("Failed to hot-reload Jenkins git credentials for %s, falling back to a full restart: %s\n", name, err)
+				err = jenk.SafeRestart()
+				if err != nil {
+					o.warnf("Failed to safe restart Jenkins after configuration change %s\n", err)
+				} else {
+					o.Printf("Safe Restarted Jenkins server\n")
+				}
+			}
 		}
 	}
 
 	return name, nil
 }
 
+// hotReloadJenkinsGitCredentials pushes the credential straight to the Jenkins Credentials
+// Plugin and asks the Git plugin to reload its server configuration, avoiding the SafeRestart
+// that would otherwise drop every running pipeline. It only touches Jenkins itself; the caller
+// falls back to SafeRestart if this returns an error.
+func (o *CommonOptions) hotReloadJenkinsGitCredentials(jenk *gojenkins.Jenkins, name string, userAuth *auth.UserAuth) error {
+	_, err := jenk.GetCredential(name)
+	if err == nil {
+		err = jenk.UpdateCredential(name, userAuth.Username, userAuth.ApiToken)
+	} else {
+		err = jenk.CreateCredential(name, userAuth.Username, userAuth.ApiToken)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to update Jenkins credential %s via the Credentials Plugin API: %s", name, err)
+	}
+
+	err = jenk.ReloadGitPluginConfig()
+	if err != nil {
+		return fmt.Errorf("Failed to reload the Jenkins Git plugin configuration: %s", err)
+	}
+	o.Printf("Hot reloaded Jenkins git server credentials for %s\n", name)
+	return nil
+}
+
 func (o *CommonOptions) ensureGitServiceCRD(server *auth.AuthServer) error {
 	kind := server.Kind
 	if kind == "" || kind == "github" || server.URL == "" {
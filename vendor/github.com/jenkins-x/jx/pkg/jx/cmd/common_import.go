@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"time"
 
+	"github.com/jenkins-x/golang-jenkins"
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jenkins"
@@ -13,13 +15,18 @@ import (
 )
 
 // ImportProject imports a MultiBranchProject into Jenkins for the given git URL
-func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile string, branchPattern, credentials string, failIfExists bool, gitProvider gits.GitProvider, authConfigSvc auth.AuthConfigService, isEnvironment bool, batchMode bool) error {
+func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile string, branchPattern, credentials string, failIfExists bool, gitProvider gits.GitProvider, authConfigSvc auth.AuthConfigService, isEnvironment bool, batchMode bool, jenkinsSelector *JenkinsSelector, healthTimeout time.Duration, retryTimeout time.Duration, webhookEngine string) error {
 
-	jenk, err := o.JenkinsClient()
+	jenk, err := o.JenkinsClientForSelector(jenkinsSelector)
 	if err != nil {
 		return err
 	}
 
+	err = jenkins.CheckHealth(util.UrlJoin(jenk.BaseURL(), "login"), healthTimeout)
+	if err != nil {
+		return fmt.Errorf("Jenkins at %s did not become healthy in time: %s", jenk.BaseURL(), err)
+	}
+
 	secrets, err := o.Factory.LoadPipelineSecrets(kube.ValueKindGit, "")
 	if err != nil {
 		return err
@@ -85,7 +92,7 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 			return fmt.Errorf("Could find a username for git server %s", u)
 		}
 
-		credentials, err = o.updatePipelineGitCredentialsSecret(server, user)
+		credentials, err = o.updatePipelineGitCredentialsSecret(server, user, "", "", nil, false)
 		if err != nil {
 			return err
 		}
@@ -96,43 +103,50 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 			createCredential = false
 		}
 	}
+	if !jenkinsSelector.IsEmpty() {
+		// namespace the credential ID by the target Jenkins App so that two Jenkins Apps
+		// importing the same git server don't end up sharing (and overwriting) one credential
+		credentials = kube.ToValidName(credentials + "-" + jenkinsSelector.Describe())
+		createCredential = true
+	}
 	if createCredential {
-		_, err = jenk.GetCredential(credentials)
+		err = jenkins.RetryTransient(retryTimeout, func() error {
+			_, err := jenk.GetCredential(credentials)
+			return err
+		})
 		if err != nil {
-			config := authConfigSvc.Config()
-			u := gitInfo.HostURL()
-			server := config.GetOrCreateServer(u)
-			if len(server.Users) == 0 {
-				// lets check if the host was used in `~/.jx/gitAuth.yaml` instead of URL
-				s2 := config.GetOrCreateServer(gitInfo.Host)
-				if s2 != nil && len(s2.Users) > 0 {
-					server = s2
-					u = gitInfo.Host
-				}
-			}
-			user, err := config.PickServerUserAuth(server, "user name for the Jenkins Pipeline", batchMode)
+			option, err := o.jenkinsCredentialOptionForImport(credentials, gitInfo, secrets, authConfigSvc, batchMode)
 			if err != nil {
 				return err
 			}
-			if user.Username == "" {
-				return fmt.Errorf("Could find a username for git server %s", u)
+			credentialXml, err := jenkins.CredentialXML(option)
+			if err != nil {
+				return err
 			}
-			err = jenk.CreateCredential(credentials, user.Username, user.ApiToken)
-
+			err = jenkins.RetryTransient(retryTimeout, func() error {
+				return jenk.CreateCredentialWithXML(credentialXml, jenkins.DefaultCredentialStore, jenkins.DefaultCredentialDomain)
+			})
 			if err != nil {
 				return fmt.Errorf("error creating jenkins credential %s at %s %v", credentials, jenk.BaseURL(), err)
 			}
-			o.Printf("Created credential %s for host %s user %s\n", util.ColorInfo(credentials), util.ColorInfo(u), util.ColorInfo(user.Username))
+			o.Printf("Created %s credential %s for host %s\n", option.Type, util.ColorInfo(credentials), util.ColorInfo(gitInfo.HostURL()))
 		}
 	}
 	org := gitInfo.Organisation
-	folder, err := jenk.GetJob(org)
+	var folder *gojenkins.Job
+	err = jenkins.RetryTransient(retryTimeout, func() error {
+		var err error
+		folder, err = jenk.GetJob(org)
+		return err
+	})
 	if err != nil {
 		// could not find folder so lets try create it
 		jobUrl := util.UrlJoin(jenk.BaseURL(), jenk.GetJobURLPath(org))
 		folderXml := jenkins.CreateFolderXml(jobUrl, org)
 		//o.Printf("XML: %s\n", folderXml)
-		err = jenk.CreateJobWithXML(folderXml, org)
+		err = jenkins.RetryTransient(retryTimeout, func() error {
+			return jenk.CreateJobWithXML(folderXml, org)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to create the %s folder in jenkins: %s", org, err)
 		}
@@ -145,7 +159,12 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 	}
 	projectXml := jenkins.CreateMultiBranchProjectXml(gitInfo, gitProvider, credentials, branchPattern, jenkinsfile)
 	jobName := gitInfo.Name
-	job, err := jenk.GetJobByPath(org, jobName)
+	var job *gojenkins.Job
+	err = jenkins.RetryTransient(retryTimeout, func() error {
+		var err error
+		job, err = jenk.GetJobByPath(org, jobName)
+		return err
+	})
 	if err == nil {
 		if failIfExists {
 			return fmt.Errorf("Job already exists in Jenkins at %s", job.Url)
@@ -154,11 +173,17 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 		}
 	} else {
 		//o.Printf("Creating MultiBranchProject %s from XML: %s\n", jobName, projectXml)
-		err = jenk.CreateFolderJobWithXML(projectXml, org, jobName)
+		err = jenkins.RetryTransient(retryTimeout, func() error {
+			return jenk.CreateFolderJobWithXML(projectXml, org, jobName)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to create MultiBranchProject job %s in folder %s due to: %s", jobName, org, err)
 		}
-		job, err = jenk.GetJobByPath(org, jobName)
+		err = jenkins.RetryTransient(retryTimeout, func() error {
+			var err error
+			job, err = jenk.GetJobByPath(org, jobName)
+			return err
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to find the MultiBranchProject job %s in folder %s due to: %s", jobName, org, err)
 		}
@@ -177,22 +202,65 @@ func (o *CommonOptions) ImportProject(gitURL string, dir string, jenkinsfile str
 		o.Printf(util.ColorStatus("Note that your first pipeline may take a few minutes to start while the necessary docker images get downloaded!\n\n"))
 
 		params := url.Values{}
-		err = jenk.Build(job, params)
+		err = jenkins.RetryTransient(retryTimeout, func() error {
+			return jenk.Build(job, params)
+		})
 		if err != nil {
 			return fmt.Errorf("Failed to trigger job %s due to %s", job.Url, err)
 		}
 
 	}
 
-	// register the webhook
-	suffix := gitProvider.JenkinsWebHookPath(gitURL, "")
-	webhookUrl := util.UrlJoin(jenk.BaseURL(), suffix)
-	webhook := &gits.GitWebHookArguments{
-		Owner: gitInfo.Organisation,
-		Repo:  gitInfo.Name,
-		URL:   webhookUrl,
+	return o.registerPipelineWebHook(jenk, gitProvider, gitInfo, gitURL, webhookEngine, retryTimeout)
+}
+
+// jenkinsCredentialOptionForImport builds the Jenkins credential to create for id, preferring an
+// SSH private key already stored in the pipeline git Secrets (e.g. one registered via
+// `jx create git token --ssh-key-file`) over prompting for a username/API token
+func (o *CommonOptions) jenkinsCredentialOptionForImport(id string, gitInfo *gits.GitRepositoryInfo, secrets *corev1.SecretList, authConfigSvc auth.AuthConfigService, batchMode bool) (jenkins.CredentialCreateOption, error) {
+	if secrets != nil {
+		for _, secret := range secrets.Items {
+			if secret.Name == id && secret.Type == corev1.SecretTypeSSHAuth {
+				username := string(secret.Data["username"])
+				if username == "" {
+					username = "git"
+				}
+				return jenkins.CredentialCreateOption{
+					ID:          id,
+					Description: fmt.Sprintf("SSH deploy key for %s", gitInfo.HostURL()),
+					Type:        jenkins.CredentialKindSSHPrivateKey,
+					Username:    username,
+					PrivateKey:  string(secret.Data[corev1.SSHAuthPrivateKey]),
+				}, nil
+			}
+		}
+	}
+
+	config := authConfigSvc.Config()
+	u := gitInfo.HostURL()
+	server := config.GetOrCreateServer(u)
+	if len(server.Users) == 0 {
+		// lets check if the host was used in `~/.jx/gitAuth.yaml` instead of URL
+		s2 := config.GetOrCreateServer(gitInfo.Host)
+		if s2 != nil && len(s2.Users) > 0 {
+			server = s2
+			u = gitInfo.Host
+		}
+	}
+	user, err := config.PickServerUserAuth(server, "user name for the Jenkins Pipeline", batchMode)
+	if err != nil {
+		return jenkins.CredentialCreateOption{}, err
+	}
+	if user.Username == "" {
+		return jenkins.CredentialCreateOption{}, fmt.Errorf("Could find a username for git server %s", u)
 	}
-	return gitProvider.CreateWebHook(webhook)
+	return jenkins.CredentialCreateOption{
+		ID:          id,
+		Description: fmt.Sprintf("API token for %s", u),
+		Type:        jenkins.CredentialKindUsernamePassword,
+		Username:    user.Username,
+		Password:    user.ApiToken,
+	}, nil
 }
 
 // findGitCredentials finds the credential name from the pipeline git Secrets
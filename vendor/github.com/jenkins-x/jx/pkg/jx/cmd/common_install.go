@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/download"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pborman/uuid"
@@ -29,37 +30,33 @@ func (o *CommonOptions) doInstallMissingDependencies(install []string) error {
 
 	for _, i := range install {
 		var err error
-		switch i {
-		case "az":
-			err = o.installAzureCli()
-		case "kubectl":
-			err = o.installKubectl()
-		case "gcloud":
-			err = o.installGcloud()
-		case "helm":
-			err = o.installHelm()
-		case "hyperkit":
-			err = o.installHyperkit()
-		case "kops":
-			err = o.installKops()
-		case "kvm":
-			err = o.installKvm()
-		case "kvm2":
-			err = o.installKvm2()
-		case "ksync":
-			_, err = o.installKSync()
-		case "minikube":
-			err = o.installMinikube()
-		case "minishift":
-			err = o.installMinishift()
-		case "oc":
-			err = o.installOc()
-		case "virtualbox":
-			err = o.installVirtualBox()
-		case "xhyve":
-			err = o.installXhyve()
-		default:
-			return fmt.Errorf("unknown dependency to install %s\n", i)
+		if installer, ok := installerRegistry[i]; ok {
+			err = installer.Install(o)
+		} else {
+			switch i {
+			case "az":
+				err = o.installAzureCli()
+			case "docker":
+				err = o.installDocker()
+			case "gcloud":
+				err = o.installGcloud()
+			case "hyperkit":
+				err = o.installHyperkit()
+			case "kvm":
+				err = o.installKvm()
+			case "kvm2":
+				err = o.installKvm2()
+			case "minishift":
+				err = o.installMinishift()
+			case "oc":
+				err = o.installOc()
+			case "virtualbox":
+				err = o.installVirtualBox()
+			case "xhyve":
+				err = o.installXhyve()
+			default:
+				return fmt.Errorf("unknown dependency to install %s\n", i)
+			}
 		}
 		if err != nil {
 			return fmt.Errorf("error installing %s: %v\n", i, err)
@@ -119,8 +116,16 @@ func (o *CommonOptions) shouldInstallBinary(binDir string, name string) (fileNam
 }
 
 func (o *CommonOptions) downloadFile(clientURL string, fullPath string) error {
+	return o.downloadFileWithChecksum(clientURL, fullPath, "")
+}
+
+// downloadFileWithChecksum downloads clientURL to fullPath via pkg/download, which retries
+// transient failures, resumes interrupted downloads and, when checksum is already known (e.g.
+// pinned by the version stream), serves the file straight out of the local download cache instead
+// of hitting the network at all
+func (o *CommonOptions) downloadFileWithChecksum(clientURL string, fullPath string, checksum string) error {
 	o.Printf("Downloading %s to %s...\n", util.ColorInfo(clientURL), util.ColorInfo(fullPath))
-	err := util.DownloadFile(fullPath, clientURL)
+	err := download.File(clientURL, fullPath, checksum)
 	if err != nil {
 		return fmt.Errorf("Unable to download file %s from %s due to: %v", fullPath, clientURL, err)
 	}
@@ -144,42 +149,18 @@ func (o *CommonOptions) installBrewIfRequired() error {
 	return o.installBrew()
 }
 
-func (o *CommonOptions) installKubectl() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.runCommand("brew", "install", "kubectl")
-	}
-	binDir, err := util.BinaryLocation()
-	if err != nil {
-		return err
-	}
-	fileName, flag, err := o.shouldInstallBinary(binDir, "kubectl")
-	if err != nil || !flag {
-		return err
-	}
-	kubernetes := "kubernetes"
-	latestVersion, err := o.getLatestVersionFromKubernetesReleaseUrl()
-	if err != nil {
-		return fmt.Errorf("Unable to get latest version for github.com/%s/%s %v", kubernetes, kubernetes, err)
-	}
+func (o *CommonOptions) installOc() error {
+	// need to fix the version we download as not able to work out the oc sha in the URL yet
+	sha := "191fece"
+	latestVersion := "3.9.0"
 
-	clientURL := fmt.Sprintf("https://storage.googleapis.com/kubernetes-release/release/v%s/bin/%s/%s/%s", latestVersion, runtime.GOOS, runtime.GOARCH, fileName)
-	fullPath := filepath.Join(binDir, fileName)
-	tmpFile := fullPath + ".tmp"
-	err = o.downloadFile(clientURL, tmpFile)
+	pinned, err := resolvePinnedVersion("oc")
 	if err != nil {
 		return err
 	}
-	err = util.RenameFile(tmpFile, fullPath)
-	if err != nil {
-		return err
+	if pinned != nil && pinned.Version != "" {
+		latestVersion = pinned.Version
 	}
-	return os.Chmod(fullPath, 0755)
-}
-
-func (o *CommonOptions) installOc() error {
-	// need to fix the version we download as not able to work out the oc sha in the URL yet
-	sha := "191fece"
-	latestVersion := "3.9.0"
 
 	binDir, err := util.BinaryLocation()
 	if err != nil {
@@ -210,14 +191,24 @@ func (o *CommonOptions) installOc() error {
 		extension = ".tar.gz"
 		clientURL += fmt.Sprintf("-%s-%s.tar.gz", runtime.GOOS, arch)
 	}
+	expectedChecksum := ""
+	if pinned != nil && pinned.URL != "" {
+		clientURL = pinned.URL
+		expectedChecksum = pinned.SHA256
+	}
 
 	fullPath := filepath.Join(binDir, fileName)
 	tarFile := filepath.Join(binDir, "oc.tgz")
 	if extension == ".zip" {
 		tarFile = filepath.Join(binDir, "oc.zip")
 	}
-	err = o.downloadFile(clientURL, tarFile)
+	err = o.downloadFileWithChecksum(clientURL, tarFile, expectedChecksum)
+	if err != nil {
+		return err
+	}
+	err = o.verifyChecksum(tarFile, clientURL, ".sha256", expectedChecksum)
 	if err != nil {
+		os.Remove(tarFile)
 		return err
 	}
 
@@ -329,6 +320,88 @@ func (o *CommonOptions) installVirtualBox() error {
 	return nil
 }
 
+// installDocker installs the Docker engine on Linux via whichever package manager is on the
+// $PATH, mirroring installBrew's approach of shelling out to the platform's own installer
+func (o *CommonOptions) installDocker() error {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	if _, err := exec.LookPath("apt-get"); err == nil {
+		return o.runCommand("sudo", "apt-get", "install", "-y", "docker.io")
+	}
+	if _, err := exec.LookPath("yum"); err == nil {
+		return o.runCommand("sudo", "yum", "install", "-y", "docker")
+	}
+	if _, err := exec.LookPath("pacman"); err == nil {
+		return o.runCommand("sudo", "pacman", "-S", "--noconfirm", "docker")
+	}
+	o.warnf("We cannot yet automate the installation of Docker on this Linux distribution - can you install this manually please?\nPlease see: https://docs.docker.com/engine/install/\n")
+	return nil
+}
+
+// minikubeDrivers returns the --vm-driver values usable on this OS, in minikube's own preference
+// order: docker first (minikube's default local driver on Linux), then the platform hypervisor
+// driver, then virtualbox as the universal fallback
+func (o *CommonOptions) minikubeDrivers() []string {
+	drivers := []string{DOCKER}
+	switch runtime.GOOS {
+	case "darwin":
+		drivers = append(drivers, "hyperkit")
+	case "linux":
+		drivers = append(drivers, "kvm2")
+	}
+	return append(drivers, "virtualbox")
+}
+
+// driverBinary returns the binary installRequirements checks for (and installs) to make driver
+// usable, e.g. "docker" itself or the "docker-machine-driver-<name>" plugin minikube shells out to
+func driverBinary(driver string) string {
+	if driver == DOCKER {
+		return DOCKER
+	}
+	return "docker-machine-driver-" + driver
+}
+
+// installMinikubeDriver installs whichever binary driverBinary(driver) names
+func (o *CommonOptions) installMinikubeDriver(driver string) error {
+	switch driver {
+	case DOCKER:
+		return o.installDocker()
+	case "hyperkit":
+		return o.installHyperkit()
+	case "kvm2":
+		return o.installKvm2()
+	default:
+		return o.installVirtualBox()
+	}
+}
+
+// pickMinikubeDriver detects which of minikubeDrivers() are available, lets the user choose
+// between them (defaulting to the first one in batch mode), installs it if it's missing, records
+// the choice on o.MinikubeVMDriver for 'jx create cluster minikube' to pass as --vm-driver, and
+// returns the selected driver name
+func (o *CommonOptions) pickMinikubeDriver() (string, error) {
+	options := o.minikubeDrivers()
+	driver := options[0]
+	if !o.BatchMode {
+		prompt := &survey.Select{
+			Message: "Minikube driver",
+			Options: options,
+			Default: options[0],
+			Help:    "The VM or container driver minikube uses to run its local Kubernetes node",
+		}
+		survey.AskOne(prompt, &driver, nil)
+	}
+	if binaryShouldBeInstalled(driverBinary(driver)) != "" {
+		err := o.installMinikubeDriver(driver)
+		if err != nil {
+			return "", err
+		}
+	}
+	o.MinikubeVMDriver = driver
+	return driver, nil
+}
+
 func (o *CommonOptions) installXhyve() error {
 	info, err := o.getCommandOutput("", "brew", "info", "docker-machine-driver-xhyve")
 
@@ -361,108 +434,13 @@ func (o *CommonOptions) installXhyve() error {
 	return nil
 }
 
-func (o *CommonOptions) installHelm() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.runCommand("brew", "install", "kubernetes-helm")
-	}
-
-	binDir, err := util.BinaryLocation()
-	if err != nil {
-		return err
-	}
-	binary := "helm"
-	fileName, flag, err := o.shouldInstallBinary(binDir, binary)
-	if err != nil || !flag {
-		return err
-	}
-	latestVersion, err := util.GetLatestVersionFromGitHub("kubernetes", "helm")
-	if err != nil {
-		return err
-	}
-	clientURL := fmt.Sprintf("https://storage.googleapis.com/kubernetes-helm/helm-v%s-%s-%s.tar.gz", latestVersion, runtime.GOOS, runtime.GOARCH)
-	fullPath := filepath.Join(binDir, fileName)
-	tarFile := fullPath + ".tgz"
-	err = o.downloadFile(clientURL, tarFile)
-	if err != nil {
-		return err
-	}
-	err = util.UnTargz(tarFile, binDir, []string{binary, fileName})
-	if err != nil {
-		return err
-	}
-	err = os.Remove(tarFile)
-	if err != nil {
-		return err
-	}
-	return os.Chmod(fullPath, 0755)
-}
+// installHelm, installKops, installKSync and installMinikube have been replaced by the
+// GitHubBinaryInstaller implementations registered in installers.go
 
 func (o *CommonOptions) getLatestJXVersion() (semver.Version, error) {
 	return util.GetLatestVersionFromGitHub("jenkins-x", "jx")
 }
 
-func (o *CommonOptions) installKops() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.runCommand("brew", "install", "kops")
-	}
-	binDir, err := util.BinaryLocation()
-	if err != nil {
-		return err
-	}
-	binary := "kops"
-	fileName, flag, err := o.shouldInstallBinary(binDir, binary)
-	if err != nil || !flag {
-		return err
-	}
-	latestVersion, err := util.GetLatestVersionFromGitHub("kubernetes", "kops")
-	if err != nil {
-		return err
-	}
-	clientURL := fmt.Sprintf("https://github.com/kubernetes/kops/releases/download/%s/kops-%s-%s", latestVersion, runtime.GOOS, runtime.GOARCH)
-	fullPath := filepath.Join(binDir, fileName)
-	tmpFile := fullPath + ".tmp"
-	err = o.downloadFile(clientURL, tmpFile)
-	if err != nil {
-		return err
-	}
-	err = util.RenameFile(tmpFile, fullPath)
-	if err != nil {
-		return err
-	}
-	return os.Chmod(fullPath, 0755)
-}
-
-func (o *CommonOptions) installKSync() (bool, error) {
-	binDir, err := util.BinaryLocation()
-	if err != nil {
-		return false, err
-	}
-	binary := "ksync"
-	fileName, flag, err := o.shouldInstallBinary(binDir, binary)
-	if err != nil || !flag {
-		return false, err
-	}
-	latestVersion, err := util.GetLatestVersionFromGitHub("vapor-ware", "ksync")
-	if err != nil {
-		return false, err
-	}
-	clientURL := fmt.Sprintf("https://github.com/vapor-ware/ksync/releases/download/%s/ksync_%s_%s", latestVersion, runtime.GOOS, runtime.GOARCH)
-	if runtime.GOOS == "windows" {
-		clientURL += ".exe"
-	}
-	fullPath := filepath.Join(binDir, fileName)
-	tmpFile := fullPath + ".tmp"
-	err = o.downloadFile(clientURL, tmpFile)
-	if err != nil {
-		return false, err
-	}
-	err = util.RenameFile(tmpFile, fullPath)
-	if err != nil {
-		return false, err
-	}
-	return true, os.Chmod(fullPath, 0755)
-}
-
 func (o *CommonOptions) installJx(upgrade bool, version string) error {
 	if runtime.GOOS == "darwin" && !o.NoBrew {
 		if upgrade {
@@ -486,53 +464,37 @@ func (o *CommonOptions) installJx(upgrade bool, version string) error {
 	}
 	org := "jenkins-x"
 	repo := "jx"
-	latestVersion, err := util.GetLatestVersionFromGitHub(org, repo)
+	pinned, err := resolvePinnedVersion(binary)
 	if err != nil {
 		return err
 	}
-	clientURL := fmt.Sprintf("https://github.com/"+org+"/"+repo+"/releases/download/v%s/"+binary+"-%s-%s.tar.gz", latestVersion, runtime.GOOS, runtime.GOARCH)
+	var clientURL, expectedChecksum string
+	if pinned != nil && pinned.Version != "" {
+		clientURL = pinned.URL
+		expectedChecksum = pinned.SHA256
+	} else {
+		latestVersion, err := util.GetLatestVersionFromGitHub(org, repo)
+		if err != nil {
+			return err
+		}
+		clientURL = fmt.Sprintf("https://github.com/"+org+"/"+repo+"/releases/download/v%s/"+binary+"-%s-%s.tar.gz", latestVersion, runtime.GOOS, runtime.GOARCH)
+	}
 	fullPath := filepath.Join(binDir, fileName)
 	tarFile := fullPath + ".tgz"
-	err = o.downloadFile(clientURL, tarFile)
-	if err != nil {
-		return err
-	}
-	err = util.UnTargz(tarFile, binDir, []string{binary, fileName})
-	if err != nil {
-		return err
-	}
-	err = os.Remove(tarFile)
-	if err != nil {
-		return err
-	}
-	return os.Chmod(fullPath, 0755)
-}
-
-func (o *CommonOptions) installMinikube() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.runCommand("brew", "cask", "install", "minikube")
-	}
-
-	binDir, err := util.BinaryLocation()
+	err = o.downloadFileWithChecksum(clientURL, tarFile, expectedChecksum)
 	if err != nil {
 		return err
 	}
-	fileName, flag, err := o.shouldInstallBinary(binDir, "minikube")
-	if err != nil || !flag {
-		return err
-	}
-	latestVersion, err := util.GetLatestVersionFromGitHub("kubernetes", "minikube")
+	err = o.verifyChecksum(tarFile, clientURL, ".sha256", expectedChecksum)
 	if err != nil {
+		os.Remove(tarFile)
 		return err
 	}
-	clientURL := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/minikube-%s-%s", latestVersion, runtime.GOOS, runtime.GOARCH)
-	fullPath := filepath.Join(binDir, fileName)
-	tmpFile := fullPath + ".tmp"
-	err = o.downloadFile(clientURL, tmpFile)
+	err = util.UnTargz(tarFile, binDir, []string{binary, fileName})
 	if err != nil {
 		return err
 	}
-	err = util.RenameFile(tmpFile, fullPath)
+	err = os.Remove(tarFile)
 	if err != nil {
 		return err
 	}
@@ -636,7 +598,7 @@ func (o *CommonOptions) getClusterDependencies(deps []string) []string {
 	return deps
 }
 
-func (o *CommonOptions) installMissingDependencies(providerSpecificDeps []string) error {
+func (o *CommonOptions) installMissingDependencies(cloudProvider string, providerSpecificDeps []string) error {
 	// get base list of required dependencies and add provider specific ones
 	deps := o.getClusterDependencies(providerSpecificDeps)
 
@@ -644,6 +606,11 @@ func (o *CommonOptions) installMissingDependencies(providerSpecificDeps []string
 		return nil
 	}
 
+	err := o.runPreflight(cloudProvider)
+	if err != nil {
+		return err
+	}
+
 	if o.BatchMode {
 		return errors.New(fmt.Sprintf("run without batch mode or mannually install missing dependencies %v\n", deps))
 	}
@@ -670,8 +637,12 @@ func (o *CommonOptions) installRequirements(cloudProvider string) error {
 		deps = o.addRequiredBinary("gcloud", deps)
 	case MINIKUBE:
 		deps = o.addRequiredBinary("minikube", deps)
+		_, err := o.pickMinikubeDriver()
+		if err != nil {
+			return err
+		}
 	}
-	return o.installMissingDependencies(deps)
+	return o.installMissingDependencies(cloudProvider, deps)
 }
 
 func (o *CommonOptions) addRequiredBinary(binName string, deps []string) []string {
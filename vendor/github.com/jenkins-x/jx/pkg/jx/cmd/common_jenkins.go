@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/spf13/cobra"
+)
+
+// JenkinsSelector identifies which Jenkins App to target on a cluster that hosts more than one,
+// such as for `jx import`. A server name is looked up in the pipeline git credentials ConfigMap,
+// a label matches against the Jenkins App's custom label, and a URL connects directly, bypassing
+// lookup entirely. Leaving every field empty selects the cluster's default Jenkins App.
+type JenkinsSelector struct {
+	ServerName string
+	Label      string
+	URL        string
+}
+
+// addFlags registers the flags used to populate this selector
+func (s *JenkinsSelector) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&s.ServerName, "jenkins", "", "", "The name of the Jenkins App to import into, if more than one is installed in this cluster")
+	cmd.Flags().StringVarP(&s.Label, "jenkins-label", "", "", "The label of the custom Jenkins App to import into, used instead of --jenkins")
+	cmd.Flags().StringVarP(&s.URL, "jenkins-url", "", "", "Overrides --jenkins and --jenkins-label and connects directly to the Jenkins master at this URL")
+}
+
+// IsEmpty returns true if none of the selector fields have been set, meaning the cluster's
+// default Jenkins App should be used
+func (s *JenkinsSelector) IsEmpty() bool {
+	return s == nil || (s.ServerName == "" && s.Label == "" && s.URL == "")
+}
+
+// Describe returns a short description of the selector - its server name, else its label, else
+// its URL, exactly as given and without sanitising - used to namespace per-Jenkins-App pipeline
+// credentials so two Jenkins Apps don't clobber each other's
+func (s *JenkinsSelector) Describe() string {
+	if s.IsEmpty() {
+		return ""
+	}
+	if s.ServerName != "" {
+		return s.ServerName
+	}
+	if s.Label != "" {
+		return s.Label
+	}
+	return s.URL
+}
+
+// JenkinsClientForSelector resolves a JenkinsSelector to a Jenkins client, falling back to the
+// cluster's default Jenkins App when the selector is empty
+func (o *CommonOptions) JenkinsClientForSelector(selector *JenkinsSelector) (*gojenkins.Jenkins, error) {
+	if selector.IsEmpty() {
+		return o.JenkinsClient()
+	}
+	return o.Factory.JenkinsClientForSelector(selector)
+}
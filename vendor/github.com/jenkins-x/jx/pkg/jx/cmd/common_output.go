@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// OutputFormatText and OutputFormatJSON are the values accepted by the --output flag added to
+// commands that support structured progress reporting, e.g. 'jx install' and 'jx delete token addon'
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+)
+
+// addOutputFlag registers the --output flag shared by commands that support Emit
+func addOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, "output", "o", OutputFormatText, "Output format for progress and results. One of: text, json")
+}
+
+// InstallEvent is a single structured progress event emitted via CommonOptions.Emit when
+// --output json is set, so a CI wrapper around 'jx install' or 'jx delete token addon' can parse
+// progress and results instead of scraping colored human-readable strings
+type InstallEvent struct {
+	Phase   string `json:"phase,omitempty"`
+	Tool    string `json:"tool,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Bytes   int64  `json:"bytes,omitempty"`
+	SHA256  string `json:"sha256,omitempty"`
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Emit writes event as a single line of JSON to o.Out when --output json is set, and does
+// nothing otherwise. It's additive - callers should keep their existing o.Printf/o.warnf calls
+// for the human-readable text mode alongside Emit
+func (o *CommonOptions) Emit(event InstallEvent) {
+	if o.Output != OutputFormatJSON {
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(o.Out, string(data))
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/download"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// PreflightResult is the outcome of a single runPreflight check
+type PreflightResult struct {
+	Name    string
+	OK      bool
+	Message string
+	Fix     string
+}
+
+const (
+	minMinikubeCPU   = 2
+	minMinikubeMemMB = 2048
+	minDefaultCPU    = 1
+	minDefaultMemMB  = 512
+)
+
+// runPreflight validates the local machine is ready to install dependencies for cloudProvider
+// before doInstallMissingDependencies scatters any binaries under util.BinaryLocation(), printing
+// actionable remediation for anything that fails. Failures abort the install unless --force was
+// passed (o.Force), in which case they're downgraded to warnings - mirroring minikube's own
+// --force preflight escape hatch
+func (o *CommonOptions) runPreflight(cloudProvider string) error {
+	results := []PreflightResult{
+		o.preflightNetwork(),
+		o.preflightResources(cloudProvider),
+		o.preflightBinDirWritable(),
+		o.preflightSudo(),
+	}
+	if cloudProvider == MINIKUBE {
+		results = append(results, o.preflightMinikubeDriver())
+	}
+
+	failed := false
+	for _, result := range results {
+		if result.OK {
+			continue
+		}
+		failed = true
+		o.warnf("Preflight check %s failed: %s\n", util.ColorInfo(result.Name), result.Message)
+		if result.Fix != "" {
+			o.warnf("  Fix: %s\n", result.Fix)
+		}
+	}
+	if failed && !o.Force {
+		return fmt.Errorf("preflight checks failed - rerun with --force to install anyway")
+	}
+	return nil
+}
+
+// preflightNetwork checks that storage.googleapis.com, github.com and any configured
+// JX_DOWNLOAD_MIRROR are reachable, since a flaky corporate proxy or an unreachable mirror aborts
+// the install partway through otherwise
+func (o *CommonOptions) preflightNetwork() PreflightResult {
+	urls := []string{"https://storage.googleapis.com/", "https://github.com/"}
+	if mirror := os.Getenv(download.MirrorEnvVar); mirror != "" {
+		urls = append(urls, mirror)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	for _, u := range urls {
+		resp, err := client.Get(u)
+		if err != nil {
+			return PreflightResult{
+				Name:    "network",
+				Message: fmt.Sprintf("Unable to reach %s: %s", u, err),
+				Fix:     "Check your internet connection or corporate proxy settings (HTTP_PROXY/HTTPS_PROXY), or set JX_DOWNLOAD_MIRROR to an internal mirror",
+			}
+		}
+		resp.Body.Close()
+	}
+	return PreflightResult{Name: "network", OK: true}
+}
+
+// preflightResources checks the CPU count and, on Linux, available RAM against cloudProvider's
+// minimums, since MINIKUBE in particular needs real headroom to run a local Kubernetes node
+func (o *CommonOptions) preflightResources(cloudProvider string) PreflightResult {
+	minCPU := minDefaultCPU
+	minMemMB := minDefaultMemMB
+	if cloudProvider == MINIKUBE {
+		minCPU = minMinikubeCPU
+		minMemMB = minMinikubeMemMB
+	}
+	cpus := runtime.NumCPU()
+	if cpus < minCPU {
+		return PreflightResult{
+			Name:    "resources",
+			Message: fmt.Sprintf("Only %d CPUs available, %s needs at least %d", cpus, cloudProvider, minCPU),
+			Fix:     "Use a machine (or VM) with more CPUs",
+		}
+	}
+	memMB, err := availableMemoryMB()
+	if err == nil && memMB > 0 && memMB < minMemMB {
+		return PreflightResult{
+			Name:    "resources",
+			Message: fmt.Sprintf("Only %dMB of RAM available, %s needs at least %dMB", memMB, cloudProvider, minMemMB),
+			Fix:     "Free up memory or use a machine with more RAM",
+		}
+	}
+	return PreflightResult{Name: "resources", OK: true}
+}
+
+// availableMemoryMB returns total system RAM in megabytes by reading /proc/meminfo on Linux, or
+// 0 with no error on platforms this isn't implemented for yet so preflightResources skips the
+// check rather than failing it
+func availableMemoryMB() (int, error) {
+	if runtime.GOOS != "linux" {
+		return 0, nil
+	}
+	data, err := ioutil.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "MemTotal:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return 0, nil
+		}
+		kb, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, err
+		}
+		return kb / 1024, nil
+	}
+	return 0, nil
+}
+
+// preflightBinDirWritable checks util.BinaryLocation() can actually be written to, since every
+// installer writes into it before being verified
+func (o *CommonOptions) preflightBinDirWritable() PreflightResult {
+	binDir, err := util.BinaryLocation()
+	if err != nil {
+		return PreflightResult{Name: "bin-dir", Message: err.Error()}
+	}
+	probe := filepath.Join(binDir, ".jx-preflight")
+	err = ioutil.WriteFile(probe, []byte("ok"), 0644)
+	if err != nil {
+		return PreflightResult{
+			Name:    "bin-dir",
+			Message: fmt.Sprintf("%s is not writable: %s", binDir, err),
+			Fix:     fmt.Sprintf("chmod/chown %s so it's writable, or add a writable directory earlier on your $PATH", binDir),
+		}
+	}
+	os.Remove(probe)
+	return PreflightResult{Name: "bin-dir", OK: true}
+}
+
+// preflightSudo checks sudo is available on darwin, where installXhyve needs it to move the
+// driver binary into /usr/local/bin
+func (o *CommonOptions) preflightSudo() PreflightResult {
+	if runtime.GOOS != "darwin" {
+		return PreflightResult{Name: "sudo", OK: true}
+	}
+	if _, err := exec.LookPath("sudo"); err != nil {
+		return PreflightResult{
+			Name:    "sudo",
+			Message: "sudo is not available, but installXhyve needs it to install the driver binary into /usr/local/bin",
+			Fix:     "Install sudo, or install the minikube driver manually as root",
+		}
+	}
+	return PreflightResult{Name: "sudo", OK: true}
+}
+
+// preflightMinikubeDriver checks that the kernel module backing o.MinikubeVMDriver is loaded on
+// Linux, since minikube fails opaquely if kvm/docker isn't actually available yet
+func (o *CommonOptions) preflightMinikubeDriver() PreflightResult {
+	driver := o.MinikubeVMDriver
+	if driver == "" || driver == DOCKER || runtime.GOOS != "linux" {
+		return PreflightResult{Name: "minikube-driver", OK: true}
+	}
+	module := driver
+	if driver == "kvm2" {
+		module = "kvm"
+	}
+	data, err := ioutil.ReadFile("/proc/modules")
+	if err != nil {
+		// can't tell either way on this kernel - don't block the install over it
+		return PreflightResult{Name: "minikube-driver", OK: true}
+	}
+	if !strings.Contains(string(data), module) {
+		return PreflightResult{
+			Name:    "minikube-driver",
+			Message: fmt.Sprintf("Kernel module %s for the %s driver is not loaded", module, driver),
+			Fix:     fmt.Sprintf("Run 'sudo modprobe %s'", module),
+		}
+	}
+	return PreflightResult{Name: "minikube-driver", OK: true}
+}
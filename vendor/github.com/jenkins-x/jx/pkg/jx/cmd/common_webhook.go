@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const webhookSecretBytes = 20
+
+// webHookSecretForRepo returns the HMAC secret used to sign webhook deliveries for gitURL,
+// reusing the Secret already registered for this git URL if one exists (the same
+// kube.AnnotationURL annotation pattern used by findGitCredentials) or generating and persisting
+// a new random one otherwise
+func (o *CommonOptions) webHookSecretForRepo(gitURL string) (string, error) {
+	client, curNs, err := o.Factory.CreateClient()
+	if err != nil {
+		return "", err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return "", err
+	}
+	secrets := client.CoreV1().Secrets(ns)
+
+	name := kube.ToValidName("jx-webhook-" + gitURL)
+	secret, getErr := secrets.Get(name, metav1.GetOptions{})
+	if getErr == nil {
+		existing := string(secret.Data[kube.SecretDataWebhookSecret])
+		if existing != "" {
+			return existing, nil
+		}
+	}
+
+	token := make([]byte, webhookSecretBytes)
+	_, err = rand.Read(token)
+	if err != nil {
+		return "", fmt.Errorf("Failed to generate a webhook secret: %s", err)
+	}
+	value := hex.EncodeToString(token)
+
+	create := getErr != nil
+	if create {
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.LabelKind:      kube.ValueKindWebhook,
+					kube.LabelCreatedBy: kube.ValueCreatedByJX,
+				},
+				Annotations: map[string]string{
+					kube.AnnotationURL: gitURL,
+				},
+			},
+			Data: map[string][]byte{},
+		}
+	}
+	secret.Data[kube.SecretDataWebhookSecret] = []byte(value)
+
+	if create {
+		_, err = secrets.Create(secret)
+	} else {
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Failed to save webhook secret for %s: %s", gitURL, err)
+	}
+	return value, nil
+}
+
+// registerPipelineWebHook registers (or reconciles, if the git provider supports it) the webhook
+// that notifies the given engine ("jenkins" or "prow") of pushes to gitURL, signing deliveries
+// with a per-repo HMAC secret that's also stored as a Jenkins secret-text credential so Jenkins'
+// git plugin can validate them
+func (o *CommonOptions) registerPipelineWebHook(jenk *gojenkins.Jenkins, gitProvider gits.GitProvider, gitInfo *gits.GitRepositoryInfo, gitURL string, engine string, retryTimeout time.Duration) error {
+	secretValue, err := o.webHookSecretForRepo(gitURL)
+	if err != nil {
+		return err
+	}
+
+	if jenk != nil {
+		credentialID := kube.ToValidName("webhook-" + gitURL)
+		err = jenkins.RetryTransient(retryTimeout, func() error {
+			_, err := jenk.GetCredential(credentialID)
+			return err
+		})
+		if err != nil {
+			option := jenkins.CredentialCreateOption{
+				ID:          credentialID,
+				Description: fmt.Sprintf("Webhook secret for %s", gitURL),
+				Type:        jenkins.CredentialKindSecretText,
+				Secret:      secretValue,
+			}
+			credentialXml, err := jenkins.CredentialXML(option)
+			if err != nil {
+				return err
+			}
+			err = jenkins.RetryTransient(retryTimeout, func() error {
+				return jenk.CreateCredentialWithXML(credentialXml, jenkins.DefaultCredentialStore, jenkins.DefaultCredentialDomain)
+			})
+			if err != nil {
+				return fmt.Errorf("error creating webhook secret credential %s at %s %v", credentialID, jenk.BaseURL(), err)
+			}
+		}
+	}
+
+	var webhookURL string
+	switch engine {
+	case webhookEngineProw:
+		ns, err := o.devNamespace()
+		if err != nil {
+			return err
+		}
+		webhookURL = fmt.Sprintf("http://hook.%s.svc.cluster.local/hook", ns)
+	default:
+		suffix := gitProvider.JenkinsWebHookPath(gitURL, "")
+		webhookURL = util.UrlJoin(jenk.BaseURL(), suffix)
+	}
+
+	webhook := &gits.GitWebHookArguments{
+		Owner:  gitInfo.Organisation,
+		Repo:   gitInfo.Name,
+		URL:    webhookURL,
+		Secret: secretValue,
+	}
+	if registrar, ok := gitProvider.(gits.WebHookRegistrar); ok {
+		return gits.ReconcileWebHook(registrar, webhook)
+	}
+	return gitProvider.CreateWebHook(webhook)
+}
+
+// devNamespace resolves the current team's dev namespace
+func (o *CommonOptions) devNamespace() (string, error) {
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	return ns, err
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const jxConfigFileName = "config.yaml"
+
+var (
+	configLong = templates.LongDesc(`
+		Manages the named Jenkins X contexts stored in $JX_HOME/config.yaml so a single workstation
+		can drive multiple Jenkins X installations without switching kube contexts.
+`)
+)
+
+// JXConfig is the persisted shape of $JX_HOME/config.yaml: a set of named contexts plus which
+// one is currently active
+type JXConfig struct {
+	CurrentContext string               `yaml:"currentContext"`
+	Contexts       map[string]JXContext `yaml:"contexts"`
+}
+
+// JXContext describes everything needed to talk to one Jenkins X installation
+type JXContext struct {
+	JenkinsURL      string `yaml:"jenkinsURL"`
+	JenkinsAuthRef  string `yaml:"jenkinsAuthRef"`
+	DevNamespace    string `yaml:"devNamespace"`
+	GitServer       string `yaml:"gitServer"`
+	DefaultTemplate string `yaml:"defaultTemplatePack"`
+}
+
+// ConfigOptions is the parent of the "jx config" sub-commands
+type ConfigOptions struct {
+	CommonOptions
+}
+
+// NewCmdConfig creates the "config" command object
+func NewCmdConfig(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "config",
+		Short:   "Views and switches between multiple Jenkins X contexts",
+		Long:    configLong,
+		Aliases: []string{"context", "contexts"},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdConfigList(f, out, errOut))
+	cmd.AddCommand(NewCmdConfigShow(f, out, errOut))
+	cmd.AddCommand(NewCmdConfigUse(f, out, errOut))
+	cmd.AddCommand(NewCmdConfigAdd(f, out, errOut))
+	cmd.AddCommand(NewCmdConfigGenerate(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigOptions) Run() error {
+	return o.Cmd.Help()
+}
+
+// jxConfigPath returns the path to $JX_HOME/config.yaml
+func jxConfigPath() string {
+	return filepath.Join(jxHomeDir(), jxConfigFileName)
+}
+
+// jxHomeDir returns $JX_HOME
+func jxHomeDir() string {
+	return os.Getenv("JX_HOME")
+}
+
+// loadJXConfig loads $JX_HOME/config.yaml, returning an empty config if it doesn't exist yet
+func loadJXConfig() (*JXConfig, error) {
+	path := jxConfigPath()
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &JXConfig{Contexts: map[string]JXContext{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config := &JXConfig{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Contexts == nil {
+		config.Contexts = map[string]JXContext{}
+	}
+	return config, nil
+}
+
+// activeJXContext returns the currently active JXContext, if $JX_HOME/config.yaml exists and
+// has one selected. Callers should fall back to their existing discovery behavior when ok is
+// false rather than treating it as an error, since most workstations won't have run
+// 'jx config add' yet
+func activeJXContext() (JXContext, bool) {
+	config, err := loadJXConfig()
+	if err != nil || config.CurrentContext == "" {
+		return JXContext{}, false
+	}
+	ctx, ok := config.Contexts[config.CurrentContext]
+	return ctx, ok
+}
+
+// saveJXConfig writes config to $JX_HOME/config.yaml, creating $JX_HOME if required
+func saveJXConfig(config *JXConfig) error {
+	dir := jxHomeDir()
+	err := os.MkdirAll(dir, DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(jxConfigPath(), data, DefaultWritePermissions)
+}
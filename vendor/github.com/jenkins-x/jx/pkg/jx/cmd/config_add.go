@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configAddLong = templates.LongDesc(`
+		Adds a named Jenkins X context to $JX_HOME/config.yaml describing a Jenkins master and
+		git server, so it can later be switched to with 'jx config use'.
+`)
+
+	configAddExample = templates.Examples(`
+		# Add a context called staging
+		jx config add staging --jenkins-url https://jenkins.staging.example.com --dev-namespace jx-staging
+	`)
+)
+
+// ConfigAddOptions the command line options for "jx config add"
+type ConfigAddOptions struct {
+	ConfigOptions
+
+	JenkinsURL      string
+	JenkinsAuthRef  string
+	DevNamespace    string
+	GitServer       string
+	DefaultTemplate string
+}
+
+// NewCmdConfigAdd creates the "config add" command object
+func NewCmdConfigAdd(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigAddOptions{
+		ConfigOptions: ConfigOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "add <name>",
+		Short:   "Adds a new Jenkins X context",
+		Long:    configAddLong,
+		Example: configAddExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.JenkinsURL, "jenkins-url", "", "", "The URL of the Jenkins master for this context")
+	cmd.Flags().StringVarP(&options.JenkinsAuthRef, "jenkins-auth-ref", "", "", "The name of the auth server entry in $JX_HOME/jenkinsAuth.yaml to use for this context")
+	cmd.Flags().StringVarP(&options.DevNamespace, "dev-namespace", "", "", "The development namespace of the Jenkins X install")
+	cmd.Flags().StringVarP(&options.GitServer, "git-server", "", "", "The default git server URL to use for this context")
+	cmd.Flags().StringVarP(&options.DefaultTemplate, "template-pack", "", "", "The default template pack to use for this context")
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigAddOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Please specify the name of the context to add")
+	}
+	name := o.Args[0]
+
+	config, err := loadJXConfig()
+	if err != nil {
+		return err
+	}
+	if _, exists := config.Contexts[name]; exists {
+		return fmt.Errorf("A context called %s already exists. Use 'jx config use %s' to switch to it", name, name)
+	}
+
+	config.Contexts[name] = JXContext{
+		JenkinsURL:      o.JenkinsURL,
+		JenkinsAuthRef:  o.JenkinsAuthRef,
+		DevNamespace:    o.DevNamespace,
+		GitServer:       o.GitServer,
+		DefaultTemplate: o.DefaultTemplate,
+	}
+	if config.CurrentContext == "" {
+		config.CurrentContext = name
+	}
+
+	err = saveJXConfig(config)
+	if err != nil {
+		return err
+	}
+	o.Printf("Added context %s\n", util.ColorInfo(name))
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configGenerateLong = templates.LongDesc(`
+		Bootstraps a named Jenkins X context from the currently active kube context: the dev
+		namespace is discovered the usual way and the default git server is picked from the
+		pipeline git Secrets, the same way 'jx import' finds credentials for the webhook.
+`)
+
+	configGenerateExample = templates.Examples(`
+		# Generate a context called staging from the current kube context
+		jx config generate staging
+	`)
+)
+
+// ConfigGenerateOptions the command line options for "jx config generate"
+type ConfigGenerateOptions struct {
+	ConfigOptions
+}
+
+// NewCmdConfigGenerate creates the "config generate" command object
+func NewCmdConfigGenerate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigGenerateOptions{
+		ConfigOptions: ConfigOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "generate <name>",
+		Short:   "Generates a Jenkins X context from the current kube context",
+		Long:    configGenerateLong,
+		Example: configGenerateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigGenerateOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Please specify the name of the context to generate")
+	}
+	name := o.Args[0]
+
+	config, err := loadJXConfig()
+	if err != nil {
+		return err
+	}
+	if _, exists := config.Contexts[name]; exists {
+		return fmt.Errorf("A context called %s already exists. Use 'jx config use %s' to switch to it", name, name)
+	}
+
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+
+	ctx := JXContext{
+		DevNamespace: ns,
+	}
+
+	jenkinsClient, err := o.Factory.CreateJenkinsClient()
+	if err == nil && jenkinsClient != nil {
+		ctx.JenkinsURL = jenkinsClient.BaseURL()
+	}
+
+	secrets, err := o.Factory.LoadPipelineSecrets(kube.ValueKindGit, "")
+	if err == nil && secrets != nil {
+		for _, secret := range secrets.Items {
+			if secret.Annotations == nil {
+				continue
+			}
+			gitURL := secret.Annotations[kube.AnnotationURL]
+			if gitURL != "" {
+				ctx.GitServer = gitURL
+				break
+			}
+		}
+	}
+
+	config.Contexts[name] = ctx
+	if config.CurrentContext == "" {
+		config.CurrentContext = name
+	}
+
+	err = saveJXConfig(config)
+	if err != nil {
+		return err
+	}
+	o.Printf("Generated context %s from namespace %s\n", util.ColorInfo(name), util.ColorInfo(ns))
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configListLong = templates.LongDesc(`
+		Lists the named Jenkins X contexts available on this workstation, marking the active one.
+`)
+
+	configListExample = templates.Examples(`
+		# List all known contexts
+		jx config list
+	`)
+)
+
+// ConfigListOptions the command line options for "jx config list"
+type ConfigListOptions struct {
+	ConfigOptions
+}
+
+// NewCmdConfigList creates the "config list" command object
+func NewCmdConfigList(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigListOptions{
+		ConfigOptions: ConfigOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the available Jenkins X contexts",
+		Long:    configListLong,
+		Example: configListExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigListOptions) Run() error {
+	config, err := loadJXConfig()
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "JENKINS URL", "DEV NAMESPACE", "ACTIVE")
+	for name, ctx := range config.Contexts {
+		active := ""
+		if name == config.CurrentContext {
+			active = util.ColorInfo("*")
+		}
+		table.AddRow(name, ctx.JenkinsURL, ctx.DevNamespace, active)
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	configShowLong = templates.LongDesc(`
+		Shows the full configuration of the active Jenkins X context, or a named one if given.
+`)
+
+	configShowExample = templates.Examples(`
+		# Show the active context
+		jx config show
+
+		# Show a specific context
+		jx config show staging
+	`)
+)
+
+// ConfigShowOptions the command line options for "jx config show"
+type ConfigShowOptions struct {
+	ConfigOptions
+}
+
+// NewCmdConfigShow creates the "config show" command object
+func NewCmdConfigShow(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigShowOptions{
+		ConfigOptions: ConfigOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "show [context]",
+		Short:   "Shows the configuration of a Jenkins X context",
+		Long:    configShowLong,
+		Example: configShowExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigShowOptions) Run() error {
+	config, err := loadJXConfig()
+	if err != nil {
+		return err
+	}
+
+	name := config.CurrentContext
+	if len(o.Args) > 0 {
+		name = o.Args[0]
+	}
+	if name == "" {
+		return fmt.Errorf("No active context is set and no context name was given. Run 'jx config list' to see the available contexts")
+	}
+
+	ctx, ok := config.Contexts[name]
+	if !ok {
+		return fmt.Errorf("No context called %s found. Run 'jx config list' to see the available contexts", name)
+	}
+
+	data, err := yaml.Marshal(ctx)
+	if err != nil {
+		return err
+	}
+	o.Printf("%s\n", name)
+	o.Printf("%s", string(data))
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configUseLong = templates.LongDesc(`
+		Switches the active Jenkins X context, so subsequent commands talk to a different
+		Jenkins master and git server without needing a 'kubectl config use-context' first.
+`)
+
+	configUseExample = templates.Examples(`
+		# Switch to the context called staging
+		jx config use staging
+	`)
+)
+
+// ConfigUseOptions the command line options for "jx config use"
+type ConfigUseOptions struct {
+	ConfigOptions
+}
+
+// NewCmdConfigUse creates the "config use" command object
+func NewCmdConfigUse(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigUseOptions{
+		ConfigOptions: ConfigOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "use <name>",
+		Short:   "Switches the active Jenkins X context",
+		Long:    configUseLong,
+		Example: configUseExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigUseOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Please specify the name of the context to use. Run 'jx config list' to see the available contexts")
+	}
+	name := o.Args[0]
+
+	config, err := loadJXConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := config.Contexts[name]; !ok {
+		return fmt.Errorf("No context called %s found. Run 'jx config list' to see the available contexts", name)
+	}
+
+	config.CurrentContext = name
+	err = saveJXConfig(config)
+	if err != nil {
+		return err
+	}
+	o.Printf("Now using context %s\n", util.ColorInfo(name))
+	return nil
+}
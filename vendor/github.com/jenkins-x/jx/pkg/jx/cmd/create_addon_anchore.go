@@ -8,8 +8,10 @@ import (
 
 	"fmt"
 
+	"net/http"
 	"time"
 
+	"github.com/jenkins-x/jx/pkg/jx/cmd/cve"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
@@ -45,9 +47,11 @@ var (
 type CreateAddonAnchoreOptions struct {
 	CreateAddonOptions
 
-	Chart     string
-	Password  string
-	ConfigDir string
+	Chart        string
+	Password     string
+	ConfigDir    string
+	PolicyBundle string
+	PolicyID     string
 }
 
 // NewCmdCreateAddonAnchore creates a command object for the "create" command
@@ -85,6 +89,8 @@ func NewCmdCreateAddonAnchore(f cmdutil.Factory, out io.Writer, errOut io.Writer
 	cmd.Flags().StringVarP(&options.Password, "password", "p", defaultAnchorePassword, "The default password to use for Anchore")
 	cmd.Flags().StringVarP(&options.ConfigDir, "config-dir", "d", defaultAnchoreConfigDir, "The config directory to use")
 	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartAnchore, "The name of the chart to use")
+	cmd.Flags().StringVarP(&options.PolicyBundle, "policy-bundle", "", "", "A local Anchore policy bundle JSON file to upload and activate instead of the Anchore default policy")
+	cmd.Flags().StringVarP(&options.PolicyID, "policy-id", "", "", "The ID of an already uploaded policy bundle to activate when --policy-bundle isn't given")
 	return cmd
 }
 
@@ -157,13 +163,26 @@ func (o *CreateAddonAnchoreOptions) Run() error {
 		return fmt.Errorf("failed to get external URL for service %s: %v", anchoreServiceName, err)
 	}
 
+	if o.PolicyBundle != "" || o.PolicyID != "" {
+		client := &http.Client{Timeout: 30 * time.Second}
+		policyID, err := applyAnchorePolicy(client, ing, "admin", o.Password, o.PolicyBundle, o.PolicyID)
+		if err != nil {
+			return fmt.Errorf("failed to apply Anchore policy: %v", err)
+		}
+		err = annotateServiceWithAnchorePolicy(o.kubeClient, o.Namespace, anchoreServiceName, policyID)
+		if err != nil {
+			return err
+		}
+		log.Infof("activated Anchore policy %s\n", policyID)
+	}
+
 	// create the local addonAuth.yaml file so `jx get cve` commands work
 	tokenOptions := CreateTokenAddonOptions{
 		Password: o.Password,
 		Username: "admin",
 		ServerFlags: ServerFlags{
 			ServerURL:  ing,
-			ServerName: anchoreDeploymentName,
+			ServerName: cve.KindAnchore,
 		},
 		Kind: kube.ValueKindCVE,
 		CreateOptions: CreateOptions{
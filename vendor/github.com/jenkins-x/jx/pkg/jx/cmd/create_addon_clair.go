@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"io"
+
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/cve"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultClairNamespace   = "clair"
+	defaultClairReleaseName = "clair"
+	defaultClairVersion     = "2.0.0"
+)
+
+var (
+	createAddonClairLong = templates.LongDesc(`
+		Creates the clair addon for scanning container images for CVEs
+`)
+
+	createAddonClairExample = templates.Examples(`
+		# Create the clair addon
+		jx create addon clair
+
+		# Create the clair addon in a custom namespace
+		jx create addon clair -n mynamespace
+	`)
+)
+
+// CreateAddonClairOptions the options for the create addon clair command
+type CreateAddonClairOptions struct {
+	CreateAddonOptions
+
+	Chart string
+}
+
+// NewCmdCreateAddonClair creates a command object for the "create addon clair" command
+func NewCmdCreateAddonClair(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonClairOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "clair",
+		Short:   "Create the Clair addon for verifying container images",
+		Long:    createAddonClairLong,
+		Example: createAddonClairExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, defaultClairNamespace, defaultClairReleaseName)
+
+	cmd.Flags().StringVarP(&options.Version, "version", "v", defaultClairVersion, "The version of the clair chart to use")
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartClair, "The name of the chart to use")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonClairOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	_, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, true, nil)
+	if err != nil {
+		return fmt.Errorf("clair deployment failed: %v", err)
+	}
+
+	log.Info("waiting for clair deployment to be ready, this can take a few minutes\n")
+
+	err = kube.WaitForDeploymentToBeReady(o.kubeClient, cve.ClairServiceName, o.Namespace, 10*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	err = kube.CreateServiceLink(o.kubeClient, o.currentNamespace, o.Namespace, cve.ClairServiceName)
+	if err != nil {
+		return fmt.Errorf("failed creating a service link for %s in target namespace %s", cve.ClairServiceName, o.Namespace)
+	}
+
+	tokenOptions := CreateTokenAddonOptions{
+		ServerFlags: ServerFlags{
+			ServerURL:  cve.ClairServiceName,
+			ServerName: cve.KindClair,
+		},
+		Kind: kube.ValueKindCVE,
+		CreateOptions: CreateOptions{
+			CommonOptions: o.CommonOptions,
+		},
+	}
+	err = tokenOptions.Run()
+	if err != nil {
+		return fmt.Errorf("failed to create addonAuth.yaml error: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultGrypeNamespace   = "grype"
+	defaultGrypeReleaseName = "grype"
+	defaultGrypeVersion     = "0.1.0"
+)
+
+var (
+	createAddonGrypeLong = templates.LongDesc(`
+		Creates the grype addon for scanning container images for CVEs
+`)
+
+	createAddonGrypeExample = templates.Examples(`
+		# Create the grype addon
+		jx create addon grype
+
+		# Create the grype addon in a custom namespace
+		jx create addon grype -n mynamespace
+	`)
+)
+
+// CreateAddonGrypeOptions the options for the create addon grype command
+type CreateAddonGrypeOptions struct {
+	CreateAddonOptions
+
+	Chart string
+}
+
+// NewCmdCreateAddonGrype creates a command object for the "create addon grype" command
+func NewCmdCreateAddonGrype(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonGrypeOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "grype",
+		Short:   "Create the grype addon for verifying container images",
+		Long:    createAddonGrypeLong,
+		Example: createAddonGrypeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, defaultGrypeNamespace, defaultGrypeReleaseName)
+
+	cmd.Flags().StringVarP(&options.Version, "version", "v", defaultGrypeVersion, "The version of the grype chart to use")
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartGrype, "The name of the chart to use")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonGrypeOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	_, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, true, nil)
+	if err != nil {
+		return fmt.Errorf("grype deployment failed: %v", err)
+	}
+
+	log.Info("waiting for grype deployment to be ready, this can take a few minutes\n")
+
+	err = kube.WaitForDeploymentToBeReady(o.kubeClient, cve.GrypeServiceName, o.Namespace, 10*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	err = kube.CreateServiceLink(o.kubeClient, o.currentNamespace, o.Namespace, cve.GrypeServiceName)
+	if err != nil {
+		return fmt.Errorf("failed creating a service link for %s in target namespace %s", cve.GrypeServiceName, o.Namespace)
+	}
+
+	tokenOptions := CreateTokenAddonOptions{
+		ServerFlags: ServerFlags{
+			ServerURL:  cve.GrypeServiceName,
+			ServerName: cve.GrypeServiceName,
+		},
+		Kind: kube.ValueKindCVE,
+		CreateOptions: CreateOptions{
+			CommonOptions: o.CommonOptions,
+		},
+	}
+	err = tokenOptions.Run()
+	if err != nil {
+		return fmt.Errorf("failed to create addonAuth.yaml error: %v", err)
+	}
+	return nil
+}
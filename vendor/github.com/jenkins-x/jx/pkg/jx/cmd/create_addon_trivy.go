@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"io"
+
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cve"
+	scancve "github.com/jenkins-x/jx/pkg/jx/cmd/cve"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultTrivyNamespace   = "trivy"
+	defaultTrivyReleaseName = "trivy"
+	defaultTrivyVersion     = "0.1.0"
+)
+
+var (
+	createAddonTrivyLong = templates.LongDesc(`
+		Creates the trivy-operator addon for scanning container images for CVEs
+`)
+
+	createAddonTrivyExample = templates.Examples(`
+		# Create the trivy addon
+		jx create addon trivy
+
+		# Create the trivy addon in a custom namespace
+		jx create addon trivy -n mynamespace
+	`)
+)
+
+// CreateAddonTrivyOptions the options for the create addon trivy command
+type CreateAddonTrivyOptions struct {
+	CreateAddonOptions
+
+	Chart string
+}
+
+// NewCmdCreateAddonTrivy creates a command object for the "create addon trivy" command
+func NewCmdCreateAddonTrivy(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonTrivyOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "trivy",
+		Short:   "Create the trivy-operator addon for verifying container images",
+		Long:    createAddonTrivyLong,
+		Example: createAddonTrivyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, defaultTrivyNamespace, defaultTrivyReleaseName)
+
+	cmd.Flags().StringVarP(&options.Version, "version", "v", defaultTrivyVersion, "The version of the trivy-operator chart to use")
+	cmd.Flags().StringVarP(&options.Chart, optionChart, "c", kube.ChartTrivy, "The name of the chart to use")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonTrivyOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	if o.Chart == "" {
+		return util.MissingOption(optionChart)
+	}
+	_, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	err = o.installChart(o.ReleaseName, o.Chart, o.Version, o.Namespace, true, nil)
+	if err != nil {
+		return fmt.Errorf("trivy-operator deployment failed: %v", err)
+	}
+
+	log.Info("waiting for trivy-operator deployment to be ready, this can take a few minutes\n")
+
+	err = kube.WaitForDeploymentToBeReady(o.kubeClient, cve.TrivyServiceName, o.Namespace, 10*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	err = kube.CreateServiceLink(o.kubeClient, o.currentNamespace, o.Namespace, cve.TrivyServiceName)
+	if err != nil {
+		return fmt.Errorf("failed creating a service link for %s in target namespace %s", cve.TrivyServiceName, o.Namespace)
+	}
+
+	tokenOptions := CreateTokenAddonOptions{
+		ServerFlags: ServerFlags{
+			ServerURL:  cve.TrivyServiceName,
+			ServerName: scancve.KindTrivy,
+		},
+		Kind: kube.ValueKindCVE,
+		CreateOptions: CreateOptions{
+			CommonOptions: o.CommonOptions,
+		},
+	}
+	err = tokenOptions.Run()
+	if err != nil {
+		return fmt.Errorf("failed to create addonAuth.yaml error: %v", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -88,6 +89,24 @@ func (o *CreateChatServerOptions) Run() error {
 	if err != nil {
 		return err
 	}
+
+	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterChatServiceCRD(apisClient)
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureChatServiceExistsForHost(jxClient, devNs, kind, name, gitUrl, o.Out)
+	if err != nil {
+		return err
+	}
+
 	o.Printf("Added issue chat server %s for URL %s\n", util.ColorInfo(name), util.ColorInfo(gitUrl))
 	return nil
 }
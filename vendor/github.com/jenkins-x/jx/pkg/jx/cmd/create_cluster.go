@@ -25,30 +25,38 @@ type CreateClusterOptions struct {
 }
 
 const (
-	GKE        = "gke"
-	EKS        = "eks"
-	AKS        = "aks"
-	AWS        = "aws"
-	MINIKUBE   = "minikube"
-	MINISHIFT  = "minishift"
-	KUBERNETES = "kubernetes"
-	OPENSHIFT  = "openshift"
-	JX_INFRA   = "jx-infra"
+	GKE          = "gke"
+	EKS          = "eks"
+	AKS          = "aks"
+	AWS          = "aws"
+	MINIKUBE     = "minikube"
+	// DOCKER is not a selectable cloud provider but minikube's preferred --vm-driver, offered
+	// first by pickMinikubeDriver since minikube promoted it to the default local driver on Linux
+	DOCKER       = "docker"
+	MINISHIFT    = "minishift"
+	KUBERNETES   = "kubernetes"
+	OPENSHIFT    = "openshift"
+	JX_INFRA     = "jx-infra"
+	DIGITALOCEAN = "digitalocean"
+	CIVO         = "civo"
 
 	optionKubernetesVersion = "kubernetes-version"
 	optionNodes             = "nodes"
 	optionClusterName       = "cluster-name"
+	optionRegion            = "region"
 )
 
-var KUBERNETES_PROVIDERS = []string{MINIKUBE, GKE, AKS, AWS, EKS, KUBERNETES, OPENSHIFT, MINISHIFT, JX_INFRA}
+var KUBERNETES_PROVIDERS = []string{MINIKUBE, GKE, AKS, AWS, EKS, KUBERNETES, OPENSHIFT, MINISHIFT, JX_INFRA, DIGITALOCEAN, CIVO}
 
 const (
 	stableKubeCtlVersionURL = "https://storage.googleapis.com/kubernetes-release/release/stable.txt"
 
 	valid_providers = `Valid kubernetes providers include:
 
-    * aks (Azure Container Service - https://docs.microsoft.com/en-us/azure/aks)
+    * aks (Azure Kubernetes Service - https://docs.microsoft.com/en-us/azure/aks)
     * aws (Amazon Web Services via kops - https://github.com/aws-samples/aws-workshop-for-kubernetes/blob/master/readme.adoc)
+    * civo (Civo Kubernetes - https://www.civo.com/kubernetes)
+    * digitalocean (DigitalOcean Kubernetes - https://www.digitalocean.com/products/kubernetes/)
     * gke (Google Container Engine - https://cloud.google.com/kubernetes-engine)
     * kubernetes for custom installations of Kubernetes
     * minikube (single-node Kubernetes cluster inside a VM on your laptop)
@@ -118,6 +126,10 @@ func NewCmdCreateCluster(f cmdutil.Factory, out io.Writer, errOut io.Writer) *co
 	cmd.AddCommand(NewCmdCreateClusterAWS(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateClusterGKE(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateClusterMinikube(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateClusterDigitalOcean(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateClusterCivo(f, out, errOut))
+	addOutputFlag(cmd, &options.Output)
+	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "Downgrade failed preflight checks to warnings instead of aborting the install")
 
 	return cmd
 }
@@ -192,7 +204,7 @@ func (o *CreateClusterOptions) getClusterDependencies(deps []string) []string {
 	return deps
 }
 
-func (o *CreateClusterOptions) installMissingDependencies(providerSpecificDeps []string) error {
+func (o *CreateClusterOptions) installMissingDependencies(cloudProvider string, providerSpecificDeps []string) error {
 
 	// get base list of required dependencies and add provider specific ones
 	deps := o.getClusterDependencies(providerSpecificDeps)
@@ -201,6 +213,11 @@ func (o *CreateClusterOptions) installMissingDependencies(providerSpecificDeps [
 		return nil
 	}
 
+	err := o.runPreflight(cloudProvider)
+	if err != nil {
+		return err
+	}
+
 	if o.BatchMode {
 		return errors.New(fmt.Sprintf("run without batch mode or mannually install missing dependencies %v\n", deps))
 	}
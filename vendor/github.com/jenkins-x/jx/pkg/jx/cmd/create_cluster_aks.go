@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CreateClusterAKSOptions the options for creating a cluster on AKS
+type CreateClusterAKSOptions struct {
+	CreateClusterOptions
+
+	Flags CreateClusterAKSFlags
+}
+
+// CreateClusterAKSFlags the flags for running create cluster on AKS
+type CreateClusterAKSFlags struct {
+	ResourceGroup string
+	ClusterName   string
+	NodeCount     string
+	KubeVersion   string
+}
+
+var (
+	createClusterAKSLong = templates.LongDesc(`
+		This command creates a new Kubernetes cluster on AKS, installing required local dependencies and provisions the Jenkins X platform
+
+		You can see a demo of this command here: [http://jenkins-x.io/demos/create_cluster/](http://jenkins-x.io/demos/create_cluster/)
+
+`)
+
+	createClusterAKSExample = templates.Examples(`
+
+		jx create cluster aks --cluster-name=jx -g my-resource-group
+
+`)
+)
+
+// NewCmdCreateClusterAKS creates a command object for the "create cluster aks" command
+func NewCmdCreateClusterAKS(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := createCreateClusterAKSOptions(f, out, errOut)
+
+	cmd := &cobra.Command{
+		Use:     "aks",
+		Short:   "Create a new Kubernetes cluster on AKS: Runs on Azure",
+		Long:    createClusterAKSLong,
+		Example: createClusterAKSExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCreateClusterFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Flags.ResourceGroup, "resource-group", "g", "", "The Azure resource group to create the cluster in")
+	cmd.Flags().StringVarP(&options.Flags.ClusterName, optionClusterName, "n", "", "The name of this cluster, defaults to jx")
+	cmd.Flags().StringVarP(&options.Flags.NodeCount, optionNodes, "o", "3", "node count")
+	cmd.Flags().StringVarP(&options.Flags.KubeVersion, optionKubernetesVersion, "v", "", "Kubernetes version to use for the cluster")
+
+	return cmd
+}
+
+func createCreateClusterAKSOptions(f cmdutil.Factory, out io.Writer, errOut io.Writer) CreateClusterAKSOptions {
+	commonOptions := createCreateClusterOptions(f, out, errOut, AKS)
+	options := CreateClusterAKSOptions{
+		CreateClusterOptions: commonOptions,
+	}
+	return options
+}
+
+// Run implements this command
+func (o *CreateClusterAKSOptions) Run() error {
+	var deps []string
+	d := binaryShouldBeInstalled("az")
+	if d != "" {
+		deps = append(deps, d)
+	}
+	err := o.installMissingDependencies(AKS, deps)
+	if err != nil {
+		return err
+	}
+
+	err = o.createClusterAKS()
+	if err != nil {
+		return err
+	}
+
+	return o.initAndInstall(AKS)
+}
+
+func (o *CreateClusterAKSOptions) createClusterAKS() error {
+	if o.Flags.ClusterName == "" {
+		o.Flags.ClusterName = "jx"
+	}
+	if o.Flags.ResourceGroup == "" {
+		return util.MissingOption("resource-group")
+	}
+
+	args := []string{"aks", "create", "--resource-group", o.Flags.ResourceGroup, "--name", o.Flags.ClusterName,
+		"--node-count", o.Flags.NodeCount, "--generate-ssh-keys"}
+	if o.Flags.KubeVersion != "" {
+		args = append(args, "--kubernetes-version", o.Flags.KubeVersion)
+	}
+
+	err := o.runCommand("az", args...)
+	if err != nil {
+		return err
+	}
+
+	return o.runCommand("az", "aks", "get-credentials", "--resource-group", o.Flags.ResourceGroup, "--name", o.Flags.ClusterName)
+}
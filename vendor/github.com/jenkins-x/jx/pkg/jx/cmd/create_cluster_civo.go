@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CreateClusterCivoOptions the options for creating a cluster on Civo
+type CreateClusterCivoOptions struct {
+	CreateClusterOptions
+
+	Flags CreateClusterCivoFlags
+}
+
+// CreateClusterCivoFlags the flags for running create cluster on Civo
+type CreateClusterCivoFlags struct {
+	ClusterName string
+	Region      string
+	NodeCount   string
+	NodeSize    string
+}
+
+var (
+	createClusterCivoLong = templates.LongDesc(`
+		This command creates a new Kubernetes cluster on Civo, installing required local dependencies and provisions the Jenkins X platform
+
+		You can see a demo of this command here: [http://jenkins-x.io/demos/create_cluster/](http://jenkins-x.io/demos/create_cluster/)
+
+`)
+
+	createClusterCivoExample = templates.Examples(`
+
+		jx create cluster civo --cluster-name=jx --region=lon1
+
+`)
+)
+
+// NewCmdCreateClusterCivo creates a command object for the "create cluster civo" command
+func NewCmdCreateClusterCivo(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := createCreateClusterCivoOptions(f, out, errOut)
+
+	cmd := &cobra.Command{
+		Use:     "civo",
+		Short:   "Create a new Kubernetes cluster on Civo: Runs on Civo Cloud",
+		Long:    createClusterCivoLong,
+		Example: createClusterCivoExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCreateClusterFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Flags.ClusterName, optionClusterName, "n", "", "The name of this cluster, defaults to jx")
+	cmd.Flags().StringVarP(&options.Flags.Region, optionRegion, "r", "lon1", "The Civo region to create the cluster in")
+	cmd.Flags().StringVarP(&options.Flags.NodeCount, optionNodes, "o", "3", "node count")
+	cmd.Flags().StringVarP(&options.Flags.NodeSize, "node-size", "", "g3.k3s.medium", "The size of the Civo nodes to use")
+
+	return cmd
+}
+
+func createCreateClusterCivoOptions(f cmdutil.Factory, out io.Writer, errOut io.Writer) CreateClusterCivoOptions {
+	commonOptions := createCreateClusterOptions(f, out, errOut, CIVO)
+	options := CreateClusterCivoOptions{
+		CreateClusterOptions: commonOptions,
+	}
+	return options
+}
+
+// Run implements this command
+func (o *CreateClusterCivoOptions) Run() error {
+	var deps []string
+	d := binaryShouldBeInstalled("civo")
+	if d != "" {
+		deps = append(deps, d)
+	}
+	err := o.installMissingDependencies(CIVO, deps)
+	if err != nil {
+		return err
+	}
+
+	err = o.createClusterCivo()
+	if err != nil {
+		return err
+	}
+
+	return o.initAndInstall(CIVO)
+}
+
+func (o *CreateClusterCivoOptions) createClusterCivo() error {
+	if o.Flags.ClusterName == "" {
+		o.Flags.ClusterName = "jx"
+	}
+	if o.Flags.Region == "" {
+		return util.MissingOption(optionRegion)
+	}
+
+	args := []string{"kubernetes", "create", o.Flags.ClusterName, "--region", o.Flags.Region,
+		"--nodes", o.Flags.NodeCount, "--size", o.Flags.NodeSize, "--wait", "--save", "--merge", "--switch"}
+
+	return o.runCommand("civo", args...)
+}
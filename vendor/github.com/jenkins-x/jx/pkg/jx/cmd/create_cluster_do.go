@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// CreateClusterDOOptions the options for creating a cluster on DigitalOcean
+type CreateClusterDOOptions struct {
+	CreateClusterOptions
+
+	Flags CreateClusterDOFlags
+}
+
+// CreateClusterDOFlags the flags for running create cluster on DigitalOcean
+type CreateClusterDOFlags struct {
+	ClusterName string
+	Region      string
+	NodeCount   string
+	KubeVersion string
+	NodeSize    string
+}
+
+var (
+	createClusterDOLong = templates.LongDesc(`
+		This command creates a new Kubernetes cluster on DigitalOcean, installing required local dependencies and provisions the Jenkins X platform
+
+		You can see a demo of this command here: [http://jenkins-x.io/demos/create_cluster/](http://jenkins-x.io/demos/create_cluster/)
+
+`)
+
+	createClusterDOExample = templates.Examples(`
+
+		jx create cluster digitalocean --cluster-name=jx --region=nyc1
+
+`)
+)
+
+// NewCmdCreateClusterDigitalOcean creates a command object for the "create cluster digitalocean" command
+func NewCmdCreateClusterDigitalOcean(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := createCreateClusterDOOptions(f, out, errOut)
+
+	cmd := &cobra.Command{
+		Use:     "digitalocean",
+		Short:   "Create a new Kubernetes cluster on DigitalOcean: Runs on DigitalOcean",
+		Long:    createClusterDOLong,
+		Example: createClusterDOExample,
+		Aliases: []string{"do", "digital-ocean"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	options.addCreateClusterFlags(cmd)
+
+	cmd.Flags().StringVarP(&options.Flags.ClusterName, optionClusterName, "n", "", "The name of this cluster, defaults to jx")
+	cmd.Flags().StringVarP(&options.Flags.Region, optionRegion, "r", "nyc1", "The DigitalOcean region to create the cluster in")
+	cmd.Flags().StringVarP(&options.Flags.NodeCount, optionNodes, "o", "3", "node count")
+	cmd.Flags().StringVarP(&options.Flags.NodeSize, "node-size", "", "s-2vcpu-4gb", "The size of the DigitalOcean droplets to use for the nodes")
+	cmd.Flags().StringVarP(&options.Flags.KubeVersion, optionKubernetesVersion, "v", "", "Kubernetes version to use for the cluster")
+
+	return cmd
+}
+
+func createCreateClusterDOOptions(f cmdutil.Factory, out io.Writer, errOut io.Writer) CreateClusterDOOptions {
+	commonOptions := createCreateClusterOptions(f, out, errOut, DIGITALOCEAN)
+	options := CreateClusterDOOptions{
+		CreateClusterOptions: commonOptions,
+	}
+	return options
+}
+
+// Run implements this command
+func (o *CreateClusterDOOptions) Run() error {
+	var deps []string
+	d := binaryShouldBeInstalled("doctl")
+	if d != "" {
+		deps = append(deps, d)
+	}
+	err := o.installMissingDependencies(DIGITALOCEAN, deps)
+	if err != nil {
+		return err
+	}
+
+	err = o.createClusterDO()
+	if err != nil {
+		return err
+	}
+
+	return o.initAndInstall(DIGITALOCEAN)
+}
+
+func (o *CreateClusterDOOptions) createClusterDO() error {
+	if o.Flags.ClusterName == "" {
+		o.Flags.ClusterName = "jx"
+	}
+	if o.Flags.Region == "" {
+		return util.MissingOption(optionRegion)
+	}
+
+	args := []string{"kubernetes", "cluster", "create", o.Flags.ClusterName,
+		"--region", o.Flags.Region, "--count", o.Flags.NodeCount, "--size", o.Flags.NodeSize, "--wait"}
+	if o.Flags.KubeVersion != "" {
+		args = append(args, "--version", o.Flags.KubeVersion)
+	}
+
+	return o.runCommand("doctl", args...)
+}
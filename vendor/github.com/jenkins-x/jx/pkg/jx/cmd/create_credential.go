@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createCredentialLong = templates.LongDesc(`
+		Creates or updates a Jenkins credential directly via the Credentials Plugin API, without
+		going through a git server or an import
+`)
+
+	createCredentialExample = templates.Examples(`
+		# Store a secret text credential, e.g. for use as a webhook HMAC secret
+		jx create credential my-webhook-secret --kind secret-text --secret suchsecret
+
+		# Store an SSH deploy key credential
+		jx create credential my-deploy-key --kind ssh-username-private-key --username git --ssh-key-file ~/.ssh/id_rsa
+
+		# Store an uploaded file credential, e.g. a kubeconfig
+		jx create credential my-kubeconfig --kind file --file ~/.kube/config
+
+		# Target a specific Jenkins App when more than one is installed in the cluster
+		jx create credential my-webhook-secret --kind secret-text --secret suchsecret --jenkins team-b
+	`)
+)
+
+// CreateCredentialOptions the options for the create credential command
+type CreateCredentialOptions struct {
+	CreateOptions
+
+	JenkinsSelector JenkinsSelector
+
+	Name        string
+	Kind        string
+	Description string
+	Store       string
+	Domain      string
+
+	Username   string
+	Password   string
+	Secret     string
+	SSHKeyFile string
+	Passphrase string
+	File       string
+}
+
+// NewCmdCreateCredential creates a command object for the "create credential" command
+func NewCmdCreateCredential(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateCredentialOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "credential [name]",
+		Short:   "Creates or updates a Jenkins credential",
+		Long:    createCredentialLong,
+		Example: createCredentialExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.JenkinsSelector.addFlags(cmd)
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", string(jenkins.CredentialKindUsernamePassword), "The kind of credential to create. Possible values: username-password, secret-text, ssh-username-private-key, file")
+	cmd.Flags().StringVarP(&options.Description, "description", "", "", "The description to store against the credential")
+	cmd.Flags().StringVarP(&options.Store, "store", "", jenkins.DefaultCredentialStore, "The Jenkins credentials store to create the credential in")
+	cmd.Flags().StringVarP(&options.Domain, "domain", "", jenkins.DefaultCredentialDomain, "The Jenkins credentials domain to create the credential in")
+	cmd.Flags().StringVarP(&options.Username, "username", "u", "", "The username, for the username-password and ssh-username-private-key kinds")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The password or API token, for the username-password kind")
+	cmd.Flags().StringVarP(&options.Secret, "secret", "s", "", "The secret text, for the secret-text kind")
+	cmd.Flags().StringVarP(&options.SSHKeyFile, "ssh-key-file", "", "", "The path to an SSH private key, for the ssh-username-private-key kind")
+	cmd.Flags().StringVarP(&options.Passphrase, "passphrase", "", "", "The passphrase protecting the SSH private key, for the ssh-username-private-key kind")
+	cmd.Flags().StringVarP(&options.File, "file", "", "", "The path to a file to upload, for the file kind")
+	options.addCommonFlags(cmd)
+
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateCredentialOptions) Run() error {
+	args := o.Args
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+	if o.Name == "" {
+		return fmt.Errorf("Missing credential name argument")
+	}
+
+	option, err := o.credentialCreateOption()
+	if err != nil {
+		return err
+	}
+
+	credentialXml, err := jenkins.CredentialXML(option)
+	if err != nil {
+		return err
+	}
+
+	jenk, err := o.JenkinsClientForSelector(&o.JenkinsSelector)
+	if err != nil {
+		return err
+	}
+
+	_, err = jenk.GetCredential(o.Name)
+	if err == nil {
+		err = jenk.UpdateCredentialWithXML(credentialXml, option.Store, option.Domain, o.Name)
+		if err != nil {
+			return fmt.Errorf("Failed to update Jenkins credential %s: %s", o.Name, err)
+		}
+		o.Printf("Updated %s credential %s\n", option.Type, util.ColorInfo(o.Name))
+		return nil
+	}
+
+	err = jenk.CreateCredentialWithXML(credentialXml, option.Store, option.Domain)
+	if err != nil {
+		return fmt.Errorf("Failed to create Jenkins credential %s: %s", o.Name, err)
+	}
+	o.Printf("Created %s credential %s\n", option.Type, util.ColorInfo(o.Name))
+	return nil
+}
+
+// credentialCreateOption builds the jenkins.CredentialCreateOption described by the command flags
+func (o *CreateCredentialOptions) credentialCreateOption() (jenkins.CredentialCreateOption, error) {
+	option := jenkins.CredentialCreateOption{
+		ID:          o.Name,
+		Description: o.Description,
+		Type:        jenkins.CredentialKind(o.Kind),
+		Store:       o.Store,
+		Domain:      o.Domain,
+	}
+
+	switch option.Type {
+	case jenkins.CredentialKindUsernamePassword:
+		if o.Username == "" || o.Password == "" {
+			return option, fmt.Errorf("--username and --password are required for kind %s", option.Type)
+		}
+		option.Username = o.Username
+		option.Password = o.Password
+
+	case jenkins.CredentialKindSecretText:
+		if o.Secret == "" {
+			return option, fmt.Errorf("--secret is required for kind %s", option.Type)
+		}
+		option.Secret = o.Secret
+
+	case jenkins.CredentialKindSSHPrivateKey:
+		if o.Username == "" || o.SSHKeyFile == "" {
+			return option, fmt.Errorf("--username and --ssh-key-file are required for kind %s", option.Type)
+		}
+		keyData, err := ioutil.ReadFile(o.SSHKeyFile)
+		if err != nil {
+			return option, fmt.Errorf("Failed to read SSH private key file %s: %s", o.SSHKeyFile, err)
+		}
+		option.Username = o.Username
+		option.PrivateKey = string(keyData)
+		option.Passphrase = o.Passphrase
+
+	case jenkins.CredentialKindFile:
+		if o.File == "" {
+			return option, fmt.Errorf("--file is required for kind %s", option.Type)
+		}
+		fileData, err := ioutil.ReadFile(o.File)
+		if err != nil {
+			return option, fmt.Errorf("Failed to read file %s: %s", o.File, err)
+		}
+		option.FileName = o.File
+		option.FileData = fileData
+
+	default:
+		return option, fmt.Errorf("Unsupported credential kind %s", o.Kind)
+	}
+	return option, nil
+}
@@ -3,12 +3,15 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/user"
 	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/cenkalti/backoff"
 	"github.com/ghodss/yaml"
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
@@ -16,18 +19,39 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	optionLabel      = "label"
-	optionRequestCpu = "request-cpu"
-	devPodGoPath     = "/home/jenkins/go"
+	optionLabel         = "label"
+	optionRequestCpu    = "request-cpu"
+	optionFromFile      = "from-file"
+	optionFromURL       = "from-url"
+	optionCreateTimeout = "create-timeout"
+	optionContainer     = "container"
+	optionPullSecret    = "pull-secret"
+	devPodGoPath        = "/home/jenkins/go"
+
+	// devPodEventPollInterval is how often streamPodEvents re-lists Events for the DevPod while
+	// waiting for it to become ready
+	devPodEventPollInterval = 5 * time.Second
 )
 
+// devPodInterestingEventReasons are the Event reasons worth surfacing to the user while a DevPod
+// is starting up, since they explain the most common causes of the "hangs for an hour" failure
+// mode that a bare readiness timeout otherwise hides
+var devPodInterestingEventReasons = map[string]bool{
+	"FailedScheduling": true,
+	"FailedMount":      true,
+	"ErrImagePull":     true,
+	"BackOff":          true,
+}
+
 var (
 	createDevPodLong = templates.LongDesc(`
 		Creates a new DevPod
@@ -49,10 +73,16 @@ var (
 type CreateDevPodOptions struct {
 	CreateOptions
 
-	Label      string
-	Suffix     string
-	WorkingDir string
-	RequestCpu string
+	Label         string
+	Suffix        string
+	WorkingDir    string
+	RequestCpu    string
+	FromFile      string
+	FromURL       string
+	CreateTimeout string
+	Proxy         bool
+	Container     string
+	PullSecrets   []string
 }
 
 // NewCmdCreateDevPod creates a command object for the "create" command
@@ -85,12 +115,30 @@ func NewCmdCreateDevPod(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cob
 	cmd.Flags().StringVarP(&options.Suffix, "suffix", "s", "", "The suffix to append the pod name")
 	cmd.Flags().StringVarP(&options.WorkingDir, "working-dir", "w", "", "The working directory of the dev pod")
 	cmd.Flags().StringVarP(&options.RequestCpu, optionRequestCpu, "c", "1.4", "The request CPU of the dev pod")
+	cmd.Flags().StringVarP(&options.FromFile, optionFromFile, "", "", "Create the DevPod from a local Pod YAML manifest instead of a pod template label, e.g. one created by 'jx export devpod'")
+	cmd.Flags().StringVarP(&options.FromURL, optionFromURL, "", "", "Create the DevPod from a Pod YAML manifest fetched from this URL instead of a pod template label")
+	cmd.Flags().StringVarP(&options.CreateTimeout, optionCreateTimeout, "", "1m", "The timeout to retry creating the DevPod's Pod for if the Kubernetes API server reports a transient error")
+	cmd.Flags().BoolVarP(&options.Proxy, "proxy", "", false, "Proxy the DevPod's container ports to localhost once it's ready, instead of opening a shell into it")
+	cmd.Flags().StringVarP(&options.Container, optionContainer, "", "", "The name of the container in the pod template to configure the working directory and resource requests on. Defaults to the first container")
+	cmd.Flags().StringArrayVarP(&options.PullSecrets, optionPullSecret, "", nil, "The name of an image pull secret to attach to the DevPod, copying it from your local ~/.docker/config.json into the dev namespace if it doesn't already exist there. Can be repeated")
 	options.addCommonFlags(cmd)
 	return cmd
 }
 
 // Run implements this command
 func (o *CreateDevPodOptions) Run() error {
+	if o.FromFile != "" && o.FromURL != "" {
+		return fmt.Errorf("Only one of --%s or --%s may be specified", optionFromFile, optionFromURL)
+	}
+	createTimeout := 1 * time.Minute
+	if o.CreateTimeout != "" {
+		d, err := time.ParseDuration(o.CreateTimeout)
+		if err != nil {
+			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.CreateTimeout, optionCreateTimeout, err)
+		}
+		createTimeout = d
+	}
+
 	client, curNs, err := o.KubeClient()
 	if err != nil {
 		return err
@@ -108,26 +156,37 @@ func (o *CreateDevPodOptions) Run() error {
 		return err
 	}
 
-	cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("Failed to find ConfigMap %s in namespace %s: %s", kube.ConfigMapJenkinsPodTemplates, ns, err)
-	}
-	podTemplates := cm.Data
-	labels := util.SortedMapKeys(podTemplates)
-
 	label := o.Label
-	if label == "" {
-		label = o.guessDevPodLabel(dir)
-	}
-	if label == "" {
-		label, err = util.PickName(labels, "Pick which kind of dev pod you wish to create: ")
+	var yml string
+	if o.FromFile != "" || o.FromURL != "" {
+		yml, err = o.loadPodManifest()
 		if err != nil {
 			return err
 		}
-	}
-	yml := podTemplates[label]
-	if yml == "" {
-		return util.InvalidOption(optionLabel, label, labels)
+		if label == "" {
+			label = "custom"
+		}
+	} else {
+		cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Failed to find ConfigMap %s in namespace %s: %s", kube.ConfigMapJenkinsPodTemplates, ns, err)
+		}
+		podTemplates := cm.Data
+		labels := util.SortedMapKeys(podTemplates)
+
+		if label == "" {
+			label = o.guessDevPodLabel(dir)
+		}
+		if label == "" {
+			label, err = util.PickName(labels, "Pick which kind of dev pod you wish to create: ")
+			if err != nil {
+				return err
+			}
+		}
+		yml = podTemplates[label]
+		if yml == "" {
+			return util.InvalidOption(optionLabel, label, labels)
+		}
 	}
 
 	o.Printf("Creating a dev pod of label: %s\n", label)
@@ -186,7 +245,10 @@ func (o *CreateDevPodOptions) Run() error {
 	if len(pod.Spec.Containers) == 0 {
 		return fmt.Errorf("No containers specified for label %s with YAML: %s", label, yml)
 	}
-	container1 := &pod.Spec.Containers[0]
+	container1, err := o.selectContainer(pod)
+	if err != nil {
+		return err
+	}
 
 	if o.RequestCpu != "" {
 		q, err := resource.ParseQuantity(o.RequestCpu)
@@ -223,7 +285,12 @@ func (o *CreateDevPodOptions) Run() error {
 		})
 	}
 
-	_, err = client.CoreV1().Pods(ns).Create(pod)
+	err = o.applyPullSecrets(client, ns, pod)
+	if err != nil {
+		return err
+	}
+
+	err = o.createPodWithRetry(client, ns, pod, createTimeout)
 	if err != nil {
 		if o.Verbose {
 			return fmt.Errorf("Failed to create pod %s\nYAML: %s", err, yml)
@@ -233,12 +300,24 @@ func (o *CreateDevPodOptions) Run() error {
 	}
 
 	o.Printf("Created pod %s - waiting for it to be ready...\n", util.ColorInfo(name))
+	stopEvents := make(chan struct{})
+	go o.streamPodEvents(client, ns, name, stopEvents)
 	err = kube.WaitForPodNameToBeReady(client, ns, name, time.Hour)
+	close(stopEvents)
 	if err != nil {
 		return err
 	}
 
 	o.Printf("Pod %s is now ready!\n", util.ColorInfo(name))
+
+	if o.Proxy {
+		restConfig, err := o.Factory.CreateClientConfig()
+		if err != nil {
+			return err
+		}
+		return runDevPodProxy(&o.CommonOptions, restConfig, ns, pod, "127.0.0.1", 0)
+	}
+
 	o.Printf("You can open other shells into this DevPod via %s\n", util.ColorInfo("jx rsh -d"))
 
 	options := &RshOptions{
@@ -291,6 +370,160 @@ func (o *CreateDevPodOptions) getOrCreateEditEnvironment() (*v1.Environment, err
 	return env, err
 }
 
+// selectContainer returns the container to apply the working directory, environment and resource
+// request overrides to - the one named by --container, or the first container in the pod if it
+// wasn't specified. The other containers in a multi-container pod template (e.g. a builder
+// sidecar plus a helper container) are left untouched.
+func (o *CreateDevPodOptions) selectContainer(pod *corev1.Pod) (*corev1.Container, error) {
+	if o.Container == "" {
+		return &pod.Spec.Containers[0], nil
+	}
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == o.Container {
+			return &pod.Spec.Containers[i], nil
+		}
+	}
+	names := []string{}
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return nil, util.InvalidOption(optionContainer, o.Container, names)
+}
+
+// applyPullSecrets appends a corev1.LocalObjectReference to pod.Spec.ImagePullSecrets for each
+// --pull-secret given, copying the Secret from the user's local docker config into the dev
+// namespace first if it doesn't already exist there, so DevPods using private registry images
+// work without a manual 'kubectl create secret docker-registry' step
+func (o *CreateDevPodOptions) applyPullSecrets(client kubernetes.Interface, ns string, pod *corev1.Pod) error {
+	for _, name := range o.PullSecrets {
+		_, err := client.CoreV1().Secrets(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			err = o.copyLocalDockerConfigSecret(client, ns, name)
+			if err != nil {
+				return err
+			}
+		}
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+	return nil
+}
+
+// copyLocalDockerConfigSecret creates a kubernetes.io/dockerconfigjson Secret called name in ns,
+// populated from the user's local ~/.docker/config.json
+func (o *CreateDevPodOptions) copyLocalDockerConfigSecret(client kubernetes.Interface, ns string, name string) error {
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	dockerConfigPath := filepath.Join(u.HomeDir, ".docker", "config.json")
+	data, err := ioutil.ReadFile(dockerConfigPath)
+	if err != nil {
+		return fmt.Errorf("Failed to read %s to create image pull secret %s: %s", dockerConfigPath, name, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: data,
+		},
+	}
+	_, err = client.CoreV1().Secrets(ns).Create(secret)
+	if err != nil {
+		return fmt.Errorf("Failed to create image pull secret %s in namespace %s: %s", name, ns, err)
+	}
+	o.Printf("Created image pull secret %s in namespace %s from %s\n", util.ColorInfo(name), util.ColorInfo(ns), util.ColorInfo(dockerConfigPath))
+	return nil
+}
+
+// createPodWithRetry creates pod in ns, retrying transient API server errors (server timeouts,
+// rate limiting, internal errors) with an exponential backoff - the same pattern the GitLab
+// Kubernetes executor uses for resource creation. Errors that indicate the request itself is bad
+// (invalid spec, forbidden, already exists) fail immediately instead of being retried.
+func (o *CreateDevPodOptions) createPodWithRetry(client kubernetes.Interface, ns string, pod *corev1.Pod, timeout time.Duration) error {
+	create := func() error {
+		_, err := client.CoreV1().Pods(ns).Create(pod)
+		if err == nil {
+			return nil
+		}
+		if kubeerrors.IsServerTimeout(err) || kubeerrors.IsTooManyRequests(err) || kubeerrors.IsInternalError(err) {
+			return err
+		}
+		return backoff.Permanent(err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 500 * time.Millisecond
+	b.MaxInterval = 30 * time.Second
+	b.MaxElapsedTime = timeout
+
+	return backoff.Retry(create, b)
+}
+
+// streamPodEvents polls for Events referencing podName in ns and prints out any with a reason in
+// devPodInterestingEventReasons as soon as they're seen, until stop is closed. This turns the
+// common "DevPod hangs for an hour" failure mode into actionable output instead of a bare timeout.
+func (o *CreateDevPodOptions) streamPodEvents(client kubernetes.Interface, ns string, podName string, stop <-chan struct{}) {
+	seen := map[string]bool{}
+	ticker := time.NewTicker(devPodEventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			events, err := client.CoreV1().Events(ns).List(metav1.ListOptions{
+				FieldSelector: "involvedObject.name=" + podName,
+			})
+			if err != nil {
+				continue
+			}
+			for _, e := range events.Items {
+				if !devPodInterestingEventReasons[e.Reason] {
+					continue
+				}
+				key := e.Name + "/" + strconv.FormatInt(int64(e.Count), 10)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				o.Printf(util.ColorWarning("%s: %s\n"), e.Reason, e.Message)
+			}
+		}
+	}
+}
+
+// loadPodManifest reads the Pod spec for a DevPod from --from-file or --from-url, as an
+// alternative to picking a pod template label from the jenkins-x-pod-templates ConfigMap. This
+// is the counterpart of 'jx export devpod', letting teams check a DevPod template into a repo
+// and re-create it elsewhere.
+func (o *CreateDevPodOptions) loadPodManifest() (string, error) {
+	if o.FromFile != "" {
+		data, err := ioutil.ReadFile(o.FromFile)
+		if err != nil {
+			return "", fmt.Errorf("Failed to read Pod manifest %s: %s", o.FromFile, err)
+		}
+		return string(data), nil
+	}
+
+	resp, err := http.Get(o.FromURL)
+	if err != nil {
+		return "", fmt.Errorf("Failed to fetch Pod manifest from %s: %s", o.FromURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to fetch Pod manifest from %s: status %d", o.FromURL, resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read Pod manifest from %s: %s", o.FromURL, err)
+	}
+	return string(data), nil
+}
+
 func (o *CreateDevPodOptions) guessDevPodLabel(dir string) string {
 	gopath := os.Getenv("GOPATH")
 	if gopath != "" {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,6 +16,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
+	"github.com/spf13/pflag"
 )
 
 const (
@@ -25,8 +27,34 @@ slug: %s
 url: %s
 ---
 `
+
+	docFormatMarkdown = "markdown"
+	docFormatMan      = "man"
+	docFormatReST     = "rest"
+	docFormatYAML     = "yaml"
+	docFormatJSON     = "json"
 )
 
+// CommandDoc is the structured representation of a single cobra command used by the json format
+type CommandDoc struct {
+	Use         string        `json:"use"`
+	Short       string        `json:"short"`
+	Long        string        `json:"long,omitempty"`
+	Example     string        `json:"example,omitempty"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Flags       []CommandFlag `json:"flags,omitempty"`
+	SubCommands []CommandDoc  `json:"subCommands,omitempty"`
+}
+
+// CommandFlag is the structured representation of a single cobra flag used by the json format
+type CommandFlag struct {
+	Name        string `json:"name"`
+	Shorthand   string `json:"shorthand,omitempty"`
+	Type        string `json:"type"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
 var (
 	create_docs_long = templates.LongDesc(`
 		Creates the documentation markdown files
@@ -42,7 +70,8 @@ var (
 type CreateDocsOptions struct {
 	CreateOptions
 
-	Dir string
+	Dir    string
+	Format string
 }
 
 // NewCmdCreateDocs creates a command object for the "create" command
@@ -72,6 +101,7 @@ func NewCmdCreateDocs(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra
 	}
 
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", ".", "the directory to generate the docs into")
+	cmd.Flags().StringVarP(&options.Format, "format", "f", docFormatMarkdown, "the format to generate the docs in. Possible values: markdown, man, rest, yaml, json")
 
 	return cmd
 }
@@ -88,6 +118,28 @@ func (o *CreateDocsOptions) Run() error {
 			return fmt.Errorf("Failed to create %s: %s", dir, err)
 		}
 	}
+
+	switch o.Format {
+	case docFormatMarkdown, "":
+		return o.generateMarkdown(jxcommand, dir)
+	case docFormatMan:
+		header := &doc.GenManHeader{
+			Title:   "JX",
+			Section: "1",
+		}
+		return doc.GenManTree(jxcommand, header, dir)
+	case docFormatReST:
+		return doc.GenReSTTree(jxcommand, dir)
+	case docFormatYAML:
+		return doc.GenYamlTree(jxcommand, dir)
+	case docFormatJSON:
+		return o.generateJSON(jxcommand, dir)
+	default:
+		return util.InvalidOption("format", o.Format, []string{docFormatMarkdown, docFormatMan, docFormatReST, docFormatYAML, docFormatJSON})
+	}
+}
+
+func (o *CreateDocsOptions) generateMarkdown(jxcommand *cobra.Command, dir string) error {
 	now := time.Now().Format(time.RFC3339)
 	prepender := func(filename string) string {
 		name := filepath.Base(filename)
@@ -107,3 +159,47 @@ func (o *CreateDocsOptions) Run() error {
 
 	return nil
 }
+
+// generateJSON walks the cobra command tree and serializes it into a single structured
+// JSON file suitable for docs sites, LSP-style tooling or shell completion generators
+func (o *CreateDocsOptions) generateJSON(jxcommand *cobra.Command, dir string) error {
+	commandDoc := commandToDoc(jxcommand)
+
+	data, err := json.MarshalIndent(commandDoc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal command tree to JSON: %s", err)
+	}
+
+	name := filepath.Join(dir, "commands.json")
+	err = ioutil.WriteFile(name, data, util.DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to write %s: %s", name, err)
+	}
+	return nil
+}
+
+func commandToDoc(cmd *cobra.Command) CommandDoc {
+	commandDoc := CommandDoc{
+		Use:     cmd.Use,
+		Short:   cmd.Short,
+		Long:    cmd.Long,
+		Example: cmd.Example,
+		Aliases: cmd.Aliases,
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		commandDoc.Flags = append(commandDoc.Flags, CommandFlag{
+			Name:        flag.Name,
+			Shorthand:   flag.Shorthand,
+			Type:        flag.Value.Type(),
+			Default:     flag.DefValue,
+			Description: flag.Usage,
+		})
+	})
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden {
+			continue
+		}
+		commandDoc.SubCommands = append(commandDoc.SubCommands, commandToDoc(sub))
+	}
+	return commandDoc
+}
@@ -3,19 +3,30 @@ package cmd
 import (
 	"io"
 
+	"encoding/json"
 	"fmt"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"io/ioutil"
 	"net/url"
+	"os/exec"
 	"strings"
 )
 
 const (
-	optionName = "name"
+	optionName        = "name"
+	optionClusterKind = "cluster-kind"
+
+	clusterKindMinikube = "minikube"
+	clusterKindKind     = "kind"
+	clusterKindK3d      = "k3d"
+	clusterKindGeneric  = "generic"
 )
 
 var (
@@ -24,17 +35,33 @@ var (
 `)
 
 	create_etc_hosts_example = templates.Examples(`
-		# Creates /etc/hosts entries for all current exposed services
+		# Creates /etc/hosts entries for all current exposed services, auto-detecting the cluster kind
 		sudo jx create etc-hosts
+
+		# Creates /etc/hosts entries, forcing discovery via a specific cluster kind
+		sudo jx create etc-hosts --cluster-kind kind
 	`)
+
+	// clusterKindDetectors maps a kind to both the substring it's recognised by in a kubeconfig
+	// context name and the CLI that must be on PATH for that discovery path to be usable
+	clusterKindDetectors = []struct {
+		kind  string
+		cli   string
+		match string
+	}{
+		{clusterKindKind, "kind", "kind"},
+		{clusterKindK3d, "k3d", "k3d"},
+		{clusterKindMinikube, "minikube", "minikube"},
+	}
 )
 
 // CreateEtcHostsOptions the options for the create spring command
 type CreateEtcHostsOptions struct {
 	CreateOptions
 
-	Name string
-	IP   string
+	Name        string
+	IP          string
+	ClusterKind string
 }
 
 // NewCmdCreateEtcHosts creates a command object for the "create" command
@@ -65,6 +92,7 @@ func NewCmdCreateEtcHosts(f cmdutil.Factory, out io.Writer, errOut io.Writer) *c
 
 	cmd.Flags().StringVarP(&options.Name, optionName, "n", "/etc/hosts", "The etc hosts file to edit")
 	cmd.Flags().StringVarP(&options.IP, "ip", "i", "", "The IP address of the node to point the host entries to")
+	cmd.Flags().StringVarP(&options.ClusterKind, optionClusterKind, "k", "", "The kind of cluster to discover the node IP for: minikube, kind, k3d or generic. Defaults to auto-detecting from the current kubeconfig context and the CLIs available on PATH")
 	return cmd
 }
 
@@ -74,22 +102,38 @@ func (o *CreateEtcHostsOptions) Run() error {
 	if name == "" {
 		return util.MissingOption(name)
 	}
+
+	f := o.Factory
+	client, ns, err := f.CreateClient()
+	if err != nil {
+		return err
+	}
+
+	contextName, err := kube.CurrentContextName()
+	if err != nil {
+		o.warnf("Failed to discover the current kubeconfig context: %s\n", err)
+		contextName = ""
+	}
+	clusterKind := o.ClusterKind
+	if clusterKind == "" {
+		clusterKind = o.detectClusterKind(contextName)
+	}
+	clusterKey := contextName
+	if clusterKey == "" {
+		clusterKey = clusterKind
+	}
+
 	if o.IP == "" {
-		// lets find a node ip
-		ip, err := o.getCommandOutput("", "minikube", "ip")
+		ip, err := o.discoverNodeIP(client, clusterKind)
 		if err != nil {
 			return err
 		}
-		o.IP = ip
+		o.IP = strings.TrimSpace(ip)
 	}
 	if o.IP == "" {
 		return fmt.Errorf("Could not discover a node IP address")
 	}
-	f := o.Factory
-	client, ns, err := f.CreateClient()
-	if err != nil {
-		return err
-	}
+
 	urls, err := kube.FindServiceURLs(client, ns)
 	if err != nil {
 		return err
@@ -107,7 +151,7 @@ func (o *CreateEtcHostsOptions) Run() error {
 	}
 	text := string(data)
 	lines := strings.Split(text, "\n")
-	idx, ipLine := o.findIPLine(&lines)
+	idx, ipLine := o.findIPLine(&lines, clusterKey)
 	for _, u := range urls {
 		ipLine = o.addUrl(u, ipLine)
 	}
@@ -123,6 +167,112 @@ func (o *CreateEtcHostsOptions) Run() error {
 	return nil
 }
 
+// detectClusterKind guesses the cluster kind from the current kubeconfig context name, only
+// trusting a match if the corresponding CLI is actually installed, and falling back to generic
+// kubectl-based discovery otherwise
+func (o *CreateEtcHostsOptions) detectClusterKind(contextName string) string {
+	lower := strings.ToLower(contextName)
+	for _, d := range clusterKindDetectors {
+		if strings.Contains(lower, d.match) {
+			if _, err := exec.LookPath(d.cli); err == nil {
+				return d.kind
+			}
+		}
+	}
+	return clusterKindGeneric
+}
+
+// discoverNodeIP finds the IP address of a cluster node to point the host entries to, dispatching
+// to the discovery mechanism appropriate for clusterKind
+func (o *CreateEtcHostsOptions) discoverNodeIP(client kubernetes.Interface, clusterKind string) (string, error) {
+	switch clusterKind {
+	case clusterKindMinikube:
+		return o.getCommandOutput("", "minikube", "ip")
+	case clusterKindKind:
+		return o.discoverKindNodeIP()
+	case clusterKindK3d:
+		return o.discoverK3dNodeIP()
+	default:
+		ip, err := o.discoverGenericNodeIP()
+		if err == nil && ip != "" {
+			return ip, nil
+		}
+		return o.discoverLoadBalancerIP(client)
+	}
+}
+
+// discoverKindNodeIP asks kind for its nodes then inspects the first one's docker network address
+func (o *CreateEtcHostsOptions) discoverKindNodeIP() (string, error) {
+	nodes, err := o.getCommandOutput("", "kind", "get", "nodes")
+	if err != nil {
+		return "", err
+	}
+	nodeNames := strings.Fields(nodes)
+	if len(nodeNames) == 0 {
+		return "", fmt.Errorf("'kind get nodes' returned no nodes")
+	}
+	format := "{{.NetworkSettings.Networks.kind.IPAddress}}"
+	return o.getCommandOutput("", "docker", "inspect", "-f", format, nodeNames[0])
+}
+
+// k3dNode is the subset of 'k3d node list -o json' we care about
+type k3dNode struct {
+	IP struct {
+		IP string `json:"IP"`
+	} `json:"IP"`
+}
+
+// discoverK3dNodeIP asks k3d for its node list and returns the first node's IP
+func (o *CreateEtcHostsOptions) discoverK3dNodeIP() (string, error) {
+	out, err := o.getCommandOutput("", "k3d", "node", "list", "-o", "json")
+	if err != nil {
+		return "", err
+	}
+	var nodes []k3dNode
+	if err := json.Unmarshal([]byte(out), &nodes); err != nil {
+		return "", fmt.Errorf("Failed to parse 'k3d node list' output: %s", err)
+	}
+	for _, n := range nodes {
+		if n.IP.IP != "" {
+			return n.IP.IP, nil
+		}
+	}
+	return "", fmt.Errorf("'k3d node list' did not return any node IPs")
+}
+
+// discoverGenericNodeIP asks kubectl for a node's InternalIP, falling back to ExternalIP, which
+// works for most real clusters as well as Docker Desktop and MicroK8s
+func (o *CreateEtcHostsOptions) discoverGenericNodeIP() (string, error) {
+	for _, addrType := range []string{"InternalIP", "ExternalIP"} {
+		jsonPath := fmt.Sprintf(`{.items[0].status.addresses[?(@.type=="%s")].address}`, addrType)
+		ip, err := o.getCommandOutput("", "kubectl", "get", "nodes", "-o", "jsonpath="+jsonPath)
+		if err == nil && strings.TrimSpace(ip) != "" {
+			return strings.TrimSpace(ip), nil
+		}
+	}
+	return "", fmt.Errorf("Could not discover a node IP address via kubectl")
+}
+
+// discoverLoadBalancerIP falls back to the first externally exposed LoadBalancer Service IP,
+// which is how an ingress controller is usually reachable on cloud clusters
+func (o *CreateEtcHostsOptions) discoverLoadBalancerIP(client kubernetes.Interface) (string, error) {
+	services, err := client.CoreV1().Services(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, svc := range services.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		for _, ing := range svc.Status.LoadBalancer.Ingress {
+			if ing.IP != "" {
+				return ing.IP, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("Could not find a LoadBalancer Service with an external IP")
+}
+
 func (o *CreateEtcHostsOptions) addUrl(serviceUrl kube.ServiceURL, ipLine string) string {
 	text := serviceUrl.URL
 	u, err := url.Parse(text)
@@ -143,16 +293,37 @@ func (o *CreateEtcHostsOptions) addUrl(serviceUrl kube.ServiceURL, ipLine string
 	return ipLine + host
 }
 
-func (o *CreateEtcHostsOptions) findIPLine(lines *[]string) (int, string) {
-	prefix := o.IP + " "
+// clusterEntriesMarker returns the comment line that identifies the block of host entries owned
+// by clusterKey, so 'jx create etc-hosts' can manage one block per cluster instead of clobbering
+// entries for other clusters when the kube-context is switched
+func clusterEntriesMarker(clusterKey string) string {
+	return fmt.Sprintf("# jx added service entries: %s", clusterKey)
+}
+
+// findIPLine finds the host entries line owned by clusterKey, creating a new marked block at the
+// end of the file if one doesn't exist yet. An existing line has its IP prefix refreshed to the
+// freshly discovered o.IP while keeping the hostnames already recorded against it.
+func (o *CreateEtcHostsOptions) findIPLine(lines *[]string, clusterKey string) (int, string) {
+	marker := clusterEntriesMarker(clusterKey)
 	for i, line := range *lines {
-		if strings.HasPrefix(line, prefix) {
-			return i, line
+		if line == marker && i+1 < len(*lines) {
+			return i + 1, o.rewriteIPPrefix((*lines)[i+1])
 		}
 	}
 
+	prefix := o.IP + " "
 	idx := len(*lines) + 2
-	*lines = append(*lines, "", "# jx added service entries", prefix)
+	*lines = append(*lines, "", marker, prefix)
 	return idx, prefix
+}
 
+// rewriteIPPrefix replaces the leading IP address of an existing host entries line with the
+// freshly discovered o.IP, keeping the hostnames that follow it
+func (o *CreateEtcHostsOptions) rewriteIPPrefix(line string) string {
+	fields := strings.Fields(line)
+	newLine := o.IP
+	if len(fields) > 1 {
+		newLine += " " + strings.Join(fields[1:], " ")
+	}
+	return newLine + " "
 }
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createGitTokenLong = templates.LongDesc(`
+		Creates a new Git token/login for a user for a given git server, storing it as a
+		pipeline credential so it can be used by Jenkins to clone and push to repositories
+`)
+
+	createGitTokenExample = templates.Examples(`
+		# Add a new API token for a user on a git server
+		jx create git token -n gitea someUserName
+
+		# As above with the token being passed in
+		jx create git token -n gitea -t someApiToken someUserName
+
+		# Register an SSH deploy key instead of an API token, e.g. for a self-hosted server
+		# that isn't reachable by API token over the public internet
+		jx create git token -n gitea --ssh-key-file ~/.ssh/id_rsa --known-hosts ~/.ssh/known_hosts someUserName
+
+		# Use an OAuth2 refresh token instead of a static API token; the access token written
+		# to the pipeline credential is obtained from the identity provider once, when this
+		# command runs, and is not refreshed afterwards
+		jx create git token -n github --oauth-refresh-token someRefreshToken --oauth-client-id someClientId --oauth-client-secret someClientSecret --oauth-token-url https://github.com/login/oauth/access_token someUserName
+	`)
+)
+
+// CreateGitTokenOptions the command line options for the command
+type CreateGitTokenOptions struct {
+	CreateOptions
+
+	ServerFlags       ServerFlags
+	Username          string
+	ApiToken          string
+	SSHKeyFile        string
+	KnownHostsFile    string
+	OAuthClientID     string
+	OAuthClientSecret string
+	OAuthAuthURL      string
+	OAuthTokenURL     string
+	OAuthRefreshToken string
+	Async             bool
+}
+
+// NewCmdCreateGitToken creates a command
+func NewCmdCreateGitToken(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateGitTokenOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "token [username]",
+		Short:   "Adds a new API token or SSH deploy key credential for a user on a git server",
+		Aliases: []string{"login"},
+		Long:    createGitTokenLong,
+		Example: createGitTokenExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	options.ServerFlags.addGitServerFlags(cmd)
+	cmd.Flags().StringVarP(&options.ApiToken, "api-token", "t", "", "The API Token for the user")
+	cmd.Flags().StringVarP(&options.SSHKeyFile, "ssh-key-file", "", "", "The path to an SSH private key to use as a deploy key credential instead of a username/API token")
+	cmd.Flags().StringVarP(&options.KnownHostsFile, "known-hosts", "", "", "The path to a known_hosts file to store alongside the SSH private key, so git clone can verify the server's host key")
+	cmd.Flags().StringVarP(&options.OAuthRefreshToken, "oauth-refresh-token", "", "", "An OAuth2 refresh token to use instead of a static API token; the access token written to the pipeline credential is obtained from it once, when this command runs, and is not refreshed afterwards")
+	cmd.Flags().StringVarP(&options.OAuthClientID, "oauth-client-id", "", "", "The OAuth2 client ID of the identity provider, used together with --oauth-refresh-token")
+	cmd.Flags().StringVarP(&options.OAuthClientSecret, "oauth-client-secret", "", "", "The OAuth2 client secret of the identity provider, used together with --oauth-refresh-token")
+	cmd.Flags().StringVarP(&options.OAuthAuthURL, "oauth-auth-url", "", "", "The OAuth2 authorization URL of the identity provider, used together with --oauth-refresh-token")
+	cmd.Flags().StringVarP(&options.OAuthTokenURL, "oauth-token-url", "", "", "The OAuth2 token URL of the identity provider, used together with --oauth-refresh-token")
+	cmd.Flags().BoolVarP(&options.Async, "async", "", false, "Don't fall back to restarting Jenkins if the credential can't be hot reloaded; useful when registering several servers back to back so only one restart is needed at the end")
+
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateGitTokenOptions) Run() error {
+	args := o.Args
+	if len(args) > 0 {
+		o.Username = args[0]
+	}
+	if len(args) > 1 {
+		o.ApiToken = args[1]
+	}
+	if o.SSHKeyFile == "" && o.KnownHostsFile != "" {
+		return fmt.Errorf("--known-hosts can only be used together with --ssh-key-file")
+	}
+	var tokenProvider auth.Provider
+	if o.OAuthRefreshToken != "" {
+		if o.SSHKeyFile != "" {
+			return fmt.Errorf("--oauth-refresh-token cannot be used together with --ssh-key-file")
+		}
+		tokenProvider = auth.NewOAuthProvider(o.OAuthClientID, o.OAuthClientSecret, o.OAuthAuthURL, o.OAuthTokenURL, o.OAuthRefreshToken)
+	}
+
+	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	config := authConfigSvc.Config()
+
+	server, err := o.findGitServer(config, &o.ServerFlags)
+	if err != nil {
+		return err
+	}
+	if o.Username == "" {
+		return fmt.Errorf("No Username specified")
+	}
+	userAuth := config.GetOrCreateUserAuth(server.URL, o.Username)
+
+	if o.SSHKeyFile == "" && tokenProvider == nil {
+		if o.ApiToken != "" {
+			userAuth.ApiToken = o.ApiToken
+		} else if userAuth.IsInvalid() {
+			f := func(username string) error {
+				o.Printf("Please generate an API Token for %s server %s\n", server.Kind, server.Label())
+				o.Printf("Then COPY the token and enter in into the form below:\n\n")
+				return nil
+			}
+			err = config.EditUserAuth(server.Label(), userAuth, o.Username, false, o.BatchMode, f)
+			if err != nil {
+				return err
+			}
+			if userAuth.IsInvalid() {
+				return fmt.Errorf("You did not properly define the user authentication!")
+			}
+		}
+	}
+
+	config.CurrentServer = server.URL
+	err = authConfigSvc.SaveConfig()
+	if err != nil {
+		return err
+	}
+
+	_, err = o.updatePipelineGitCredentialsSecret(server, userAuth, o.SSHKeyFile, o.KnownHostsFile, tokenProvider, o.Async)
+	if err != nil {
+		return fmt.Errorf("Failed to update pipeline git credentials secret: %s", err)
+	}
+
+	o.Printf("Created git credential for user %s on server %s at %s\n",
+		util.ColorInfo(o.Username), util.ColorInfo(server.Name), util.ColorInfo(server.URL))
+	return nil
+}
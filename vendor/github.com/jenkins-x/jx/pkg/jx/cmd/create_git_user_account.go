@@ -12,11 +12,69 @@ import (
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
+// GitUserProvisioner creates a new user account directly on a git server. Different git
+// server kinds provision users in different ways (e.g. exec'ing into the server's own pod vs
+// calling an admin REST API), so each kind implements this interface and is looked up by
+// gitUserProvisionerForKind.
+type GitUserProvisioner interface {
+	// CreateUser provisions a new user account with the given credentials
+	CreateUser(username string, password string, email string, isAdmin bool) error
+}
+
+// gitUserProvisionerForKind returns the GitUserProvisioner for the given git server kind
+func gitUserProvisionerForKind(o *CreateGitUserOptions, kind string) (GitUserProvisioner, error) {
+	switch kind {
+	case "gitea":
+		client, ns, err := o.Factory.CreateClient()
+		if err != nil {
+			return nil, err
+		}
+		return &giteaUserProvisioner{o: o, client: client, ns: ns}, nil
+	default:
+		return nil, fmt.Errorf("Creating users is not yet supported for git servers of kind %s", kind)
+	}
+}
+
+// giteaUserProvisioner provisions users on a Gitea server by exec'ing the gitea CLI inside its pod
+type giteaUserProvisioner struct {
+	o      *CreateGitUserOptions
+	client kubernetes.Interface
+	ns     string
+}
+
+func (p *giteaUserProvisioner) CreateUser(username string, password string, email string, isAdmin bool) error {
+	o := p.o
+	deploymentName := "gitea-gitea"
+	log.Infof("Waiting for pods to be ready for deployment %s\n", deploymentName)
+
+	err := kube.WaitForDeploymentToBeReady(p.client, deploymentName, p.ns, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	pods, err := kube.GetDeploymentPods(p.client, deploymentName, p.ns)
+	if pods == nil || len(pods) == 0 {
+		return fmt.Errorf("No pod found for namespace %s with name %s", p.ns, deploymentName)
+	}
+
+	command := "/app/gitea/gitea admin create-user --name " + username + " --password " + password
+	if email != "" {
+		command += " --email " + email
+	}
+	if isAdmin {
+		command += " --admin"
+	}
+	// default to using the first pods found if more than one exists for the deployment
+	return o.runCommand("kubectl", "exec", "-t", pods[0].Name, "--", "/bin/sh", "-c", command)
+}
+
 var (
 	create_git_user_long = templates.LongDesc(`
-		Creates a new user for a Git Server. Only supported for Gitea so far
+		Creates a new user for a Git Server. Currently supported for Gitea; other git server kinds
+		return an error until their own GitUserProvisioner is implemented
 `)
 
 	create_git_user_example = templates.Examples(`
@@ -91,12 +149,6 @@ func (o *CreateGitUserOptions) Run() error {
 		return err
 	}
 
-	kind := server.Kind
-	if kind != "gitea" {
-		return fmt.Errorf("Only git servers of kind %s are supported right now", "gitea")
-	}
-
-	// TODO add the API thingy...
 	if o.Username == "" {
 		return fmt.Errorf("No Username specified")
 	}
@@ -104,37 +156,15 @@ func (o *CreateGitUserOptions) Run() error {
 		return fmt.Errorf("No password specified")
 	}
 
-	client, ns, err := o.Factory.CreateClient()
+	provisioner, err := gitUserProvisionerForKind(o, server.Kind)
 	if err != nil {
 		return err
 	}
-
-	deploymentName := "gitea-gitea"
-	log.Infof("Waiting for pods to be ready for deployment %s\n", deploymentName)
-
-	err = kube.WaitForDeploymentToBeReady(client, deploymentName, ns, 5*time.Minute)
+	err = provisioner.CreateUser(o.Username, o.Password, o.Email, o.IsAdmin)
 	if err != nil {
 		return err
 	}
 
-	pods, err := kube.GetDeploymentPods(client, deploymentName, ns)
-	if pods == nil || len(pods) == 0 {
-		return fmt.Errorf("No pod found for namespace %s with name %s", ns, deploymentName)
-	}
-
-	command := "/app/gitea/gitea admin create-user --admin --name " + o.Username + " --password " + o.Password
-	if o.Email != "" {
-		command += " --email " + o.Email
-	}
-	if o.IsAdmin {
-		command += " --admin"
-	}
-	// default to using the first pods found if more than one exists for the deployment
-	err = o.runCommand("kubectl", "exec", "-t", pods[0].Name, "--", "/bin/sh", "-c", command)
-	if err != nil {
-		return nil
-	}
-
 	o.Printf("Created user %s API Token for git server %s at %s\n",
 		util.ColorInfo(o.Username), util.ColorInfo(server.Name), util.ColorInfo(server.URL))
 	return nil
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	pipelinetemplates "github.com/jenkins-x/jx/pkg/pipeline/templates"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createTemplateLong = templates.LongDesc(`
+		Scaffolds a new template pack under $JX_HOME/templates so 'jx import' can use it to
+		generate the Jenkinsfile, Dockerfile.release and chart skeleton for a project
+
+`)
+
+	createTemplateExample = templates.Examples(`
+		# Create a new template pack called "python", copying the maven pack as a starting point
+		jx create template python
+
+		# Create a new template pack based on the "go" pack
+		jx create template rust --from go
+	`)
+)
+
+// CreateTemplateOptions the options for the "create template" command
+type CreateTemplateOptions struct {
+	CreateOptions
+
+	From string
+}
+
+// NewCmdCreateTemplate creates a command object for the "create template" command
+func NewCmdCreateTemplate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateTemplateOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "template <name>",
+		Short:   "Scaffolds a new jx import template pack",
+		Long:    createTemplateLong,
+		Example: createTemplateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.From, "from", "", pipelinetemplates.DefaultPackName, "The existing template pack to copy as a starting point")
+
+	return cmd
+}
+
+// Run implements this command
+func (o *CreateTemplateOptions) Run() error {
+	args := o.Args
+	if len(args) == 0 {
+		return util.MissingArgument("name")
+	}
+	name := args[0]
+
+	home := os.Getenv("JX_HOME")
+	if home == "" {
+		return fmt.Errorf("no $JX_HOME environment variable set so we don't know where to scaffold the template pack")
+	}
+
+	dir := filepath.Join(home, "templates", name)
+	exists, err := util.FileExists(dir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("template pack %s already exists at %s", name, dir)
+	}
+
+	from, err := pipelinetemplates.LoadPack("", o.From)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("Failed to create %s: %s", dir, err)
+	}
+	for file, content := range from.Files {
+		path := filepath.Join(dir, file)
+		err = ioutil.WriteFile(path, []byte(content), util.DefaultWritePermissions)
+		if err != nil {
+			return fmt.Errorf("Failed to write %s: %s", path, err)
+		}
+	}
+
+	o.Printf("Created template pack %s at %s\n", util.ColorInfo(name), util.ColorInfo(dir))
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/addon"
 	"github.com/jenkins-x/jx/pkg/auth"
@@ -13,6 +14,16 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// linkServiceAccountPollInterval is how often linkServiceAccountTokenSecret re-checks the
+	// ServiceAccount while waiting for its token Secret to materialize
+	linkServiceAccountPollInterval = time.Second
+	// linkServiceAccountPollTimeout is how long linkServiceAccountTokenSecret waits before giving
+	// up, rather than leaving the addon credential half-wired
+	linkServiceAccountPollTimeout = 10 * time.Second
 )
 
 var (
@@ -39,6 +50,8 @@ type CreateTokenAddonOptions struct {
 	ApiToken    string
 	Timeout     string
 	Kind        string
+
+	LinkServiceAccount string
 }
 
 // NewCmdCreateTokenAddon creates a command
@@ -72,6 +85,7 @@ func NewCmdCreateTokenAddon(f cmdutil.Factory, out io.Writer, errOut io.Writer)
 	cmd.Flags().StringVarP(&options.ApiToken, "api-token", "t", "", "The API Token for the user")
 	cmd.Flags().StringVarP(&options.Timeout, "timeout", "", "", "The timeout if using browser automation to generate the API token (by passing username and password)")
 	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "The kind of addon. Defaults to the addon name if not specified")
+	cmd.Flags().StringVarP(&options.LinkServiceAccount, "link-serviceaccount", "", "", "The name of a ServiceAccount in the dev namespace whose token Secret should be linked to the addon credentials secret once it materializes")
 
 	return cmd
 }
@@ -140,23 +154,28 @@ func (o *CreateTokenAddonOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	err = o.updateAddonCredentialsSecret(server, userAuth)
+	secretName, err := o.updateAddonCredentialsSecret(server, userAuth)
 	if err != nil {
 		o.warnf("Failed to update addon credentials secret: %v\n", err)
+	} else if o.LinkServiceAccount != "" {
+		err = o.linkServiceAccountTokenSecret(secretName, o.LinkServiceAccount)
+		if err != nil {
+			return err
+		}
 	}
 	o.Printf("Created user %s API Token for addon server %s at %s\n",
 		util.ColorInfo(o.Username), util.ColorInfo(server.Name), util.ColorInfo(server.URL))
 	return nil
 }
 
-func (o *CreateTokenAddonOptions) updateAddonCredentialsSecret(server *auth.AuthServer, userAuth *auth.UserAuth) error {
+func (o *CreateTokenAddonOptions) updateAddonCredentialsSecret(server *auth.AuthServer, userAuth *auth.UserAuth) (string, error) {
 	client, curNs, err := o.Factory.CreateClient()
 	if err != nil {
-		return err
+		return "", err
 	}
 	ns, _, err := kube.GetDevNamespace(client, curNs)
 	if err != nil {
-		return err
+		return "", err
 	}
 	options := metav1.GetOptions{}
 	name := kube.ToValidName(kube.SecretJenkinsPipelineAddonCredentials + server.Kind + "-" + server.Name)
@@ -206,7 +225,70 @@ func (o *CreateTokenAddonOptions) updateAddonCredentialsSecret(server *auth.Auth
 		_, err = secrets.Update(secret)
 	}
 	if err != nil {
-		return fmt.Errorf("Failed to %s secret %s due to %s", operation, secret.Name, err)
+		return "", fmt.Errorf("Failed to %s secret %s due to %s", operation, secret.Name, err)
+	}
+	return secret.Name, nil
+}
+
+// linkServiceAccountTokenSecret polls serviceAccountName in the dev namespace until a Secret
+// reference of type SecretTypeServiceAccountToken with non-empty token data appears, then
+// annotates the addon credentials secret with its name so a Jenkins pipeline credential binding
+// can resolve it deterministically rather than guessing the auto-generated Secret name
+func (o *CreateTokenAddonOptions) linkServiceAccountTokenSecret(addonSecretName string, serviceAccountName string) error {
+	client, curNs, err := o.Factory.CreateClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+
+	tokenSecretName, err := pollServiceAccountTokenSecret(client, ns, serviceAccountName)
+	if err != nil {
+		return err
+	}
+
+	secrets := client.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(addonSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find addon credentials secret %s in namespace %s: %s", addonSecretName, ns, err)
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[kube.AnnotationServiceAccountTokenSecret] = tokenSecretName
+	_, err = secrets.Update(secret)
+	if err != nil {
+		return fmt.Errorf("Failed to link token secret %s to addon credentials secret %s: %s", tokenSecretName, addonSecretName, err)
 	}
+	o.Printf("Linked ServiceAccount token secret %s to addon credentials secret %s\n", util.ColorInfo(tokenSecretName), util.ColorInfo(addonSecretName))
 	return nil
 }
+
+// pollServiceAccountTokenSecret polls serviceAccountName in ns until one of its Secret references
+// is a populated SecretTypeServiceAccountToken Secret, returning its name. Jenkins ServiceAccounts
+// take a few seconds after creation for Kubernetes to generate and attach this token, so a single
+// read is not reliable here.
+func pollServiceAccountTokenSecret(client kubernetes.Interface, ns string, serviceAccountName string) (string, error) {
+	end := time.Now().Add(linkServiceAccountPollTimeout)
+	for {
+		sa, err := client.CoreV1().ServiceAccounts(ns).Get(serviceAccountName, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("Failed to find ServiceAccount %s in namespace %s: %s", serviceAccountName, ns, err)
+		}
+		for _, ref := range sa.Secrets {
+			secret, err := client.CoreV1().Secrets(ns).Get(ref.Name, metav1.GetOptions{})
+			if err != nil {
+				continue
+			}
+			if secret.Type == v1.SecretTypeServiceAccountToken && len(secret.Data["token"]) > 0 {
+				return secret.Name, nil
+			}
+		}
+		if time.Now().After(end) {
+			return "", fmt.Errorf("Timed out after %s waiting for a %s token Secret to appear for ServiceAccount %s in namespace %s", linkServiceAccountPollTimeout, v1.SecretTypeServiceAccountToken, serviceAccountName, ns)
+		}
+		time.Sleep(linkServiceAccountPollInterval)
+	}
+}
@@ -0,0 +1,127 @@
+package cve
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	anchorePollInterval = 5 * time.Second
+	anchorePollTimeout  = 10 * time.Minute
+)
+
+// AnchoreScanner drives the Anchore Engine REST API
+type AnchoreScanner struct{}
+
+// Kind returns "anchore"
+func (s *AnchoreScanner) Kind() string { return KindAnchore }
+
+// AuthKind returns the kube.Value* constant Anchore's credentials are filed under
+func (s *AnchoreScanner) AuthKind() string { return kube.ValueKindCVE }
+
+// Install returns the Helm chart coordinates used to deploy Anchore Engine
+func (s *AnchoreScanner) Install() InstallConfig {
+	return InstallConfig{
+		Chart:          kube.ChartAnchore,
+		DefaultVersion: "0.1.4",
+		ServiceName:    "anchore-anchore-engine",
+		DeploymentName: "anchore-anchore-engine-core",
+	}
+}
+
+// ServiceURL resolves the external URL of the Anchore Engine API
+func (s *AnchoreScanner) ServiceURL(kubeClient kubernetes.Interface, namespace string) (string, error) {
+	return serviceURL(kubeClient, s.Install().ServiceName, namespace)
+}
+
+type anchoreImageSummary struct {
+	ImageDigest string `json:"imageDigest"`
+}
+
+type anchoreCheckStatus struct {
+	ImageDigest    string `json:"imageDigest"`
+	AnalysisStatus string `json:"analysis_status"`
+}
+
+type anchoreVulnerability struct {
+	Vuln     string `json:"vuln"`
+	Severity string `json:"severity"`
+	Package  string `json:"package"`
+	Fix      string `json:"fix"`
+	URL      string `json:"url"`
+}
+
+type anchoreVulnerabilityReport struct {
+	Vulnerabilities []anchoreVulnerability `json:"vulnerabilities"`
+}
+
+type anchorePolicyEvaluation struct {
+	Status string `json:"status"`
+}
+
+// ScanImage POSTs image to Anchore Engine, polls until it's analyzed and returns its report
+func (s *AnchoreScanner) ScanImage(client *http.Client, serverURL string, username string, password string, image string) (*Report, error) {
+	body := map[string]string{"tag": image}
+	summaries := []anchoreImageSummary{}
+	err := doJSONRequest(client, serverURL, username, password, "POST", "/v1/images", body, &summaries)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 || summaries[0].ImageDigest == "" {
+		return nil, fmt.Errorf("anchore engine did not return an image digest for %s", image)
+	}
+	digest := summaries[0].ImageDigest
+
+	deadline := time.Now().Add(anchorePollTimeout)
+	for {
+		statuses := []anchoreCheckStatus{}
+		err := doJSONRequest(client, serverURL, username, password, "GET", "/v1/images/"+digest+"/check", nil, &statuses)
+		if err == nil && len(statuses) > 0 {
+			switch statuses[0].AnalysisStatus {
+			case "analyzed":
+				return s.GetReport(client, serverURL, username, password, digest)
+			case "analysis_failed":
+				return nil, fmt.Errorf("anchore engine failed to analyze image %s", image)
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for Anchore Engine to analyze %s", anchorePollTimeout, image)
+		}
+		time.Sleep(anchorePollInterval)
+	}
+}
+
+// GetReport fetches the vulnerability report and policy evaluation for an already-analyzed digest
+func (s *AnchoreScanner) GetReport(client *http.Client, serverURL string, username string, password string, digest string) (*Report, error) {
+	vulnReport := &anchoreVulnerabilityReport{}
+	err := doJSONRequest(client, serverURL, username, password, "GET", "/v1/images/"+digest+"/vuln/all", nil, vulnReport)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := []anchorePolicyEvaluation{}
+	err = doJSONRequest(client, serverURL, username, password, "GET", "/v1/images/"+digest+"/check?detail=true", nil, &evaluations)
+	if err != nil {
+		return nil, err
+	}
+	status := "unknown"
+	if len(evaluations) > 0 {
+		status = evaluations[0].Status
+	}
+
+	result := &Report{Digest: digest, PolicyStatus: status}
+	for _, v := range vulnReport.Vulnerabilities {
+		result.Vulnerabilities = append(result.Vulnerabilities, Vulnerability{
+			Vuln:     v.Vuln,
+			Severity: v.Severity,
+			Package:  v.Package,
+			Fix:      v.Fix,
+			URL:      v.URL,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package cve
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ClairServiceName is the name of the in-cluster service exposed by the clair addon
+const ClairServiceName = "clair"
+
+const (
+	clairPollInterval = 5 * time.Second
+	clairPollTimeout  = 10 * time.Minute
+)
+
+// ClairScanner drives the Clair v4 indexer/matcher REST API
+type ClairScanner struct{}
+
+// Kind returns "clair"
+func (s *ClairScanner) Kind() string { return KindClair }
+
+// AuthKind returns the kube.Value* constant Clair's credentials are filed under
+func (s *ClairScanner) AuthKind() string { return kube.ValueKindCVE }
+
+// Install returns the Helm chart coordinates used to deploy Clair
+func (s *ClairScanner) Install() InstallConfig {
+	return InstallConfig{
+		Chart:          kube.ChartClair,
+		DefaultVersion: "2.0.0",
+		ServiceName:    ClairServiceName,
+		DeploymentName: ClairServiceName,
+	}
+}
+
+// ServiceURL resolves the external URL of the Clair API
+func (s *ClairScanner) ServiceURL(kubeClient kubernetes.Interface, namespace string) (string, error) {
+	return serviceURL(kubeClient, s.Install().ServiceName, namespace)
+}
+
+type clairIndexReport struct {
+	ManifestHash string `json:"manifest_hash"`
+	State        string `json:"state"`
+}
+
+type clairPackage struct {
+	Name string `json:"name"`
+}
+
+type clairVulnerability struct {
+	Name           string       `json:"name"`
+	Severity       string       `json:"severity"`
+	Package        clairPackage `json:"package"`
+	FixedInVersion string       `json:"fixed_in_version"`
+	Links          string       `json:"links"`
+}
+
+type clairVulnerabilityReport struct {
+	Vulnerabilities map[string]clairVulnerability `json:"vulnerabilities"`
+}
+
+// ScanImage POSTs image's manifest hash to Clair's indexer, polls until indexing finishes and
+// returns its matched vulnerability report
+func (s *ClairScanner) ScanImage(client *http.Client, serverURL string, username string, password string, image string) (*Report, error) {
+	body := map[string]string{"hash": image}
+	report := &clairIndexReport{}
+	err := doJSONRequest(client, serverURL, username, password, "POST", "/indexer/api/v1/index_report", body, report)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(clairPollTimeout)
+	for report.State != "IndexFinished" {
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for Clair to index %s", clairPollTimeout, image)
+		}
+		time.Sleep(clairPollInterval)
+		err = doJSONRequest(client, serverURL, username, password, "GET", "/indexer/api/v1/index_report/"+report.ManifestHash, nil, report)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.GetReport(client, serverURL, username, password, report.ManifestHash)
+}
+
+// GetReport fetches the matcher's vulnerability report for an already-indexed manifest hash
+func (s *ClairScanner) GetReport(client *http.Client, serverURL string, username string, password string, digest string) (*Report, error) {
+	vulnReport := &clairVulnerabilityReport{}
+	err := doJSONRequest(client, serverURL, username, password, "GET", "/matcher/api/v1/vulnerability_report/"+digest, nil, vulnReport)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Report{Digest: digest, PolicyStatus: "n/a"}
+	for _, v := range vulnReport.Vulnerabilities {
+		result.Vulnerabilities = append(result.Vulnerabilities, Vulnerability{
+			Vuln:     v.Name,
+			Severity: v.Severity,
+			Package:  v.Package.Name,
+			Fix:      v.FixedInVersion,
+			URL:      v.Links,
+		})
+	}
+	return result, nil
+}
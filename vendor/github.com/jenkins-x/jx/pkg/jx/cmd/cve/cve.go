@@ -0,0 +1,131 @@
+// Package cve provides a pluggable interface over the CVE scanning backends (Anchore, Clair,
+// Trivy) that 'jx create addon', 'jx scan image' and 'jx get cve' drive, so adding a new backend
+// means implementing Scanner rather than threading another --provider case through every command
+package cve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+)
+
+// the addon kinds of the supported CVE scanners. Each 'jx create addon' command persists its
+// Kind as the ServerName of the addonAuth.yaml entry it writes, so later commands can look the
+// Scanner back up without the user having to pass --provider every time
+const (
+	KindAnchore = "anchore"
+	KindClair   = "clair"
+	KindTrivy   = "trivy"
+)
+
+// Vulnerability is a single vulnerability row returned by a scanner
+type Vulnerability struct {
+	Vuln     string
+	Severity string
+	Package  string
+	Fix      string
+	URL      string
+}
+
+// Report is the result of scanning an image: the vulnerabilities found and, where the scanner
+// supports it, the policy evaluation outcome
+type Report struct {
+	Digest          string
+	Vulnerabilities []Vulnerability
+	PolicyStatus    string
+}
+
+// InstallConfig is the declarative Helm chart configuration a Scanner needs installed, so the
+// 'jx create addon' commands can share one install/expose/wait routine across scanners
+type InstallConfig struct {
+	Chart          string
+	DefaultVersion string
+	ServiceName    string
+	DeploymentName string
+}
+
+// Scanner is implemented by each CVE scanning backend so 'jx create addon', 'jx scan image' and
+// 'jx get cve' are written once and dispatch on whichever scanner a team installed
+type Scanner interface {
+	// Kind returns the scanner's addon kind, e.g. "anchore", "clair" or "trivy"
+	Kind() string
+	// AuthKind returns the kube.Value* constant this scanner's credentials are filed under
+	AuthKind() string
+	// Install returns the Helm chart coordinates used to deploy this scanner
+	Install() InstallConfig
+	// ServiceURL resolves the external URL of the scanner's API once it's running in namespace
+	ServiceURL(kubeClient kubernetes.Interface, namespace string) (string, error)
+	// ScanImage submits image for analysis and blocks until its vulnerability report is ready
+	ScanImage(client *http.Client, serverURL string, username string, password string, image string) (*Report, error)
+	// GetReport re-fetches the report for an image already scanned, identified by digest
+	GetReport(client *http.Client, serverURL string, username string, password string, digest string) (*Report, error)
+}
+
+// NewScanner returns the Scanner registered for kind, defaulting to Anchore for backwards
+// compatibility with addonAuth.yaml entries written before this package existed
+func NewScanner(kind string) (Scanner, error) {
+	switch kind {
+	case "", KindAnchore:
+		return &AnchoreScanner{}, nil
+	case KindClair:
+		return &ClairScanner{}, nil
+	case KindTrivy:
+		return &TrivyScanner{}, nil
+	default:
+		return nil, fmt.Errorf("unknown CVE scanner kind %s, possible values: %s, %s, %s", kind, KindAnchore, KindClair, KindTrivy)
+	}
+}
+
+// doJSONRequest makes an authenticated JSON request against a scanner's API rooted at serverURL,
+// decoding the response body into out if it's non-nil. Shared by the Scanner implementations so
+// they all talk to their backend the same way
+func doJSONRequest(client *http.Client, serverURL string, username string, password string, method string, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(serverURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("scanner returned status %d from %s: %s", resp.StatusCode, path, string(data))
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// serviceURL looks up the external URL exposecontroller assigned to serviceName, shared by the
+// Scanner implementations' ServiceURL methods
+func serviceURL(kubeClient kubernetes.Interface, serviceName string, namespace string) (string, error) {
+	return kube.GetServiceURLFromName(kubeClient, serviceName, namespace)
+}
@@ -0,0 +1,50 @@
+package cve
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+)
+
+// TrivyServiceName is the name of the in-cluster service exposed by the trivy-operator addon
+const TrivyServiceName = "trivy-operator"
+
+// TrivyScanner integrates with the trivy-operator addon. Unlike Anchore/Clair, trivy-operator
+// watches images already running in the cluster and reports vulnerabilities via
+// VulnerabilityReport CRDs rather than a request/response scan API, so it can't satisfy an
+// on-demand ScanImage/GetReport call the way the other scanners can
+type TrivyScanner struct{}
+
+// Kind returns "trivy"
+func (s *TrivyScanner) Kind() string { return KindTrivy }
+
+// AuthKind returns the kube.Value* constant trivy-operator's credentials are filed under
+func (s *TrivyScanner) AuthKind() string { return kube.ValueKindCVE }
+
+// Install returns the Helm chart coordinates used to deploy trivy-operator
+func (s *TrivyScanner) Install() InstallConfig {
+	return InstallConfig{
+		Chart:          kube.ChartTrivy,
+		DefaultVersion: "0.1.0",
+		ServiceName:    TrivyServiceName,
+		DeploymentName: TrivyServiceName,
+	}
+}
+
+// ServiceURL resolves the external URL of the trivy-operator service
+func (s *TrivyScanner) ServiceURL(kubeClient kubernetes.Interface, namespace string) (string, error) {
+	return serviceURL(kubeClient, s.Install().ServiceName, namespace)
+}
+
+// ScanImage is not supported: trivy-operator scans images automatically as they run in the
+// cluster, reporting through VulnerabilityReport CRDs rather than an on-demand API
+func (s *TrivyScanner) ScanImage(client *http.Client, serverURL string, username string, password string, image string) (*Report, error) {
+	return nil, fmt.Errorf("trivy-operator scans images automatically as they run in the cluster and reports through VulnerabilityReport CRDs; 'jx scan image' does not run trivy scans on demand")
+}
+
+// GetReport is not supported: see ScanImage
+func (s *TrivyScanner) GetReport(client *http.Client, serverURL string, username string, password string, digest string) (*Report, error) {
+	return nil, fmt.Errorf("trivy-operator reports are read from VulnerabilityReport CRDs, not fetched by digest")
+}
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os/user"
+	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jenkins-x/golang-jenkins"
@@ -14,11 +17,23 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/util"
 )
 
+// helmReleaseLabel is the label that Helm charts commonly stamp on every resource they create,
+// set to the release name. The jx promotion pipeline installs each app as a Helm release named
+// after the app, so this is also the label we poll on to tell when an app's resources are gone
+const helmReleaseLabel = "release"
+
+// deleteAppResourcePollInterval is how often waitForResourcesDeleted re-lists the environment
+// namespace while waiting for an app's resources to actually disappear after the GitOps PR merges
+const deleteAppResourcePollInterval = 5 * time.Second
+
 var (
 	deleteAppLong = templates.LongDesc(`
 		Deletes one or more Applications from Jenkins
@@ -48,6 +63,11 @@ type DeleteAppOptions struct {
 	NoMergePullRequest  bool
 	Timeout             string
 	PullRequestPollTime string
+	WaitForDelete       bool
+	MaxConcurrentEnvs   int
+	EnvFilter           string
+	EnvOrder            string
+	Output              string
 
 	// calculated fields
 	TimeoutDuration         *time.Duration
@@ -81,8 +101,13 @@ func NewCmdDeleteApp(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.
 	cmd.Flags().BoolVarP(&options.SelectAll, "all", "a", false, "Selects all the matched apps")
 	cmd.Flags().BoolVarP(&options.NoMergePullRequest, "no-merge", "", false, "Disables automatic merge of promote Pull Requests")
 	cmd.Flags().StringVarP(&options.SelectFilter, "filter", "f", "", "Filter the list of apps to those containing this text")
-	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "1h", "The timeout to wait for the promotion to succeed in the underlying Environment. The command fails if the timeout is exceeded or the promotion does not complete")
+	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "1h", "The timeout to wait for the promotion to succeed in the underlying Environment, including waiting for the app's resources to terminate if --wait-for-delete is set. The command fails if the timeout is exceeded or the promotion does not complete")
 	cmd.Flags().StringVarP(&options.PullRequestPollTime, optionPullRequestPollTime, "", "20s", "Poll time when waiting for a Pull Request to merge")
+	cmd.Flags().BoolVarP(&options.WaitForDelete, "wait-for-delete", "", false, "Wait for the app's Kubernetes resources to actually terminate in each Environment after the delete Pull Request merges, not just for the PR to merge")
+	cmd.Flags().IntVarP(&options.MaxConcurrentEnvs, "max-concurrent-envs", "", 4, "Maximum number of Environments to process concurrently")
+	cmd.Flags().StringVarP(&options.EnvFilter, "env-filter", "", "", "Only delete the app from Environments whose name matches this glob, e.g. 'staging-*'")
+	cmd.Flags().StringVarP(&options.EnvOrder, "env-order", "", "", "Comma separated list of Environment names to process one at a time, in this order, before the remaining Environments run concurrently, e.g. 'staging,production'")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", "table", "Output format for the final delete report: table, json or yaml")
 
 	return cmd
 }
@@ -188,31 +213,163 @@ func (o *DeleteAppOptions) deleteApp(jenkinsClient *gojenkins.Jenkins, name stri
 	}
 
 	appName := o.appNameFromJenkinsJobName(name)
+
+	envNames, err = o.filterEnvNames(envNames)
+	if err != nil {
+		return err
+	}
+
+	states := []*envDeleteState{}
+	stateMap := map[string]*envDeleteState{}
 	for _, envName := range envNames {
-		// TODO filter on environment names?
-		env := envMap[envName]
-		if env != nil {
-			err = o.deleteAppFromEnvironment(env, appName, u.Username)
-			if err != nil {
-				return err
+		state := newEnvDeleteState(envName)
+		states = append(states, state)
+		stateMap[envName] = state
+	}
+	out := newDeleteAppOutput(o, states)
+
+	maxConcurrent := o.MaxConcurrentEnvs
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	for _, wave := range o.envDeletionWaves(envNames) {
+		concurrency := maxConcurrent
+		if len(wave) < concurrency {
+			concurrency = len(wave)
+		}
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for _, envName := range wave {
+			env := envMap[envName]
+			if env == nil {
+				continue
 			}
+			state := stateMap[envName]
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(env *v1.Environment, state *envDeleteState) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				err := o.deleteAppFromEnvironment(env, appName, u.Username, state, out)
+				if err != nil {
+					state.setError(err)
+				}
+				out.render()
+			}(env, state)
 		}
+		wg.Wait()
+	}
+
+	report := DeleteAppReport{App: appName}
+	envErrors := []string{}
+	for _, state := range states {
+		snap := state.snapshot()
+		report.Environments = append(report.Environments, snap)
+		if snap.Status == envDeleteStatusFailed {
+			envErrors = append(envErrors, fmt.Sprintf("environment %s: %s", snap.Environment, snap.Error))
+		}
+	}
+	err = o.printDeleteAppReport(report, states)
+	if err != nil {
+		return err
+	}
+	if len(envErrors) > 0 {
+		return fmt.Errorf("Failed to remove app %s from %d environment(s):\n%s", appName, len(envErrors), strings.Join(envErrors, "\n"))
 	}
 
 	// lets try delete the job from each environment first
 	return jenkinsClient.DeleteJob(*job)
 }
 
+// filterEnvNames narrows envNames down to those matching --env-filter, if set
+func (o *DeleteAppOptions) filterEnvNames(envNames []string) ([]string, error) {
+	if o.EnvFilter == "" {
+		return envNames, nil
+	}
+	answer := []string{}
+	for _, name := range envNames {
+		matched, err := path.Match(o.EnvFilter, name)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid --env-filter glob %s: %s", o.EnvFilter, err)
+		}
+		if matched {
+			answer = append(answer, name)
+		}
+	}
+	return answer, nil
+}
+
+// envDeletionWaves splits envNames into the order they should be processed in. Each name listed
+// in --env-order runs alone, in the order given, so e.g. "staging,production" guarantees staging
+// finishes before production starts. Every environment not mentioned there runs concurrently
+// together in one final wave, bounded by --max-concurrent-envs
+func (o *DeleteAppOptions) envDeletionWaves(envNames []string) [][]string {
+	if o.EnvOrder == "" {
+		return [][]string{envNames}
+	}
+
+	ordered := []string{}
+	for _, name := range strings.Split(o.EnvOrder, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && util.StringArrayIndex(envNames, name) >= 0 && util.StringArrayIndex(ordered, name) < 0 {
+			ordered = append(ordered, name)
+		}
+	}
+
+	remaining := []string{}
+	for _, name := range envNames {
+		if util.StringArrayIndex(ordered, name) < 0 {
+			remaining = append(remaining, name)
+		}
+	}
+
+	waves := [][]string{}
+	for _, name := range ordered {
+		waves = append(waves, []string{name})
+	}
+	if len(remaining) > 0 {
+		waves = append(waves, remaining)
+	}
+	return waves
+}
+
+// printDeleteAppReport renders the final summary of the delete: a live table by default, or
+// JSON/YAML when --output asks for it so a CI pipeline can consume the result programmatically
+func (o *DeleteAppOptions) printDeleteAppReport(report DeleteAppReport, states []*envDeleteState) error {
+	switch o.Output {
+	case "", "table":
+		o.renderDeleteAppTable(states)
+		return nil
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		o.Printf("%s\n", string(data))
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		o.Printf("%s\n", string(data))
+		return nil
+	default:
+		return fmt.Errorf("Unsupported --output format %s, must be one of: table, json, yaml", o.Output)
+	}
+}
+
 func (o *DeleteAppOptions) appNameFromJenkinsJobName(name string) string {
 	path := strings.Split(name, "/")
 	return path[len(path)-1]
 }
 
-func (o *DeleteAppOptions) deleteAppFromEnvironment(env *v1.Environment, appName string, username string) error {
+func (o *DeleteAppOptions) deleteAppFromEnvironment(env *v1.Environment, appName string, username string, state *envDeleteState, out *deleteAppOutput) error {
 	if env.Spec.Source.URL == "" {
 		return nil
 	}
-	o.Printf("Removing app %s from environment %s\n", appName, env.Spec.Label)
+	out.printf("Removing app %s from environment %s\n", appName, env.Spec.Label)
 
 	branchName := "delete-" + appName
 	title := "Delete application " + appName + " from this environment"
@@ -226,18 +383,35 @@ func (o *DeleteAppOptions) deleteAppFromEnvironment(env *v1.Environment, appName
 	if err != nil {
 		return err
 	}
+	if info != nil {
+		state.setPullRequest(info.PullRequest.URL)
+		state.setStatus(envDeleteStatusPROpen)
+		out.render()
+	}
 
 	duration := *o.TimeoutDuration
 	end := time.Now().Add(duration)
 
-	return o.waitForGitOpsPullRequest(env, info, end, duration)
+	err = o.waitForGitOpsPullRequest(env, info, end, duration, state, out)
+	if err != nil {
+		return err
+	}
+	if o.WaitForDelete {
+		err = o.waitForResourcesDeleted(env, appName, end, out)
+		if err != nil {
+			return err
+		}
+		state.setStatus(envDeleteStatusVerifiedDeleted)
+		out.render()
+	}
+	return nil
 }
 
-func (o *DeleteAppOptions) waitForGitOpsPullRequest(env *v1.Environment, pullRequestInfo *ReleasePullRequestInfo, end time.Time, duration time.Duration) error {
+func (o *DeleteAppOptions) waitForGitOpsPullRequest(env *v1.Environment, pullRequestInfo *ReleasePullRequestInfo, end time.Time, duration time.Duration, state *envDeleteState, out *deleteAppOutput) error {
 	if pullRequestInfo != nil {
 		logMergeFailure := false
 		pr := pullRequestInfo.PullRequest
-		o.Printf("Waiting for pull request %s to merge\n", pr.URL)
+		out.printf("Waiting for pull request %s to merge\n", pr.URL)
 
 		for {
 			gitProvider := pullRequestInfo.GitProvider
@@ -247,26 +421,31 @@ func (o *DeleteAppOptions) waitForGitOpsPullRequest(env *v1.Environment, pullReq
 			}
 
 			if pr.Merged != nil && *pr.Merged {
-				o.Printf("Pull Request %s is merged!\n", util.ColorInfo(pr.URL))
+				out.printf("Pull Request %s is merged!\n", util.ColorInfo(pr.URL))
+				state.setStatus(envDeleteStatusMerged)
+				out.render()
 				return nil
 			} else {
 				if pr.IsClosed() {
-					o.warnf("Pull Request %s is closed\n", util.ColorInfo(pr.URL))
+					out.warnf("Pull Request %s is closed\n", util.ColorInfo(pr.URL))
 					return fmt.Errorf("Promotion failed as Pull Request %s is closed without merging", pr.URL)
 				}
 				// lets try merge if the status is good
 				status, err := gitProvider.PullRequestLastCommitStatus(pr)
 				if err != nil {
-					o.warnf("Failed to query the Pull Request last commit status for %s ref %s %s\n", pr.URL, pr.LastCommitSha, err)
+					out.warnf("Failed to query the Pull Request last commit status for %s ref %s %s\n", pr.URL, pr.LastCommitSha, err)
 					//return fmt.Errorf("Failed to query the Pull Request last commit status for %s ref %s %s", pr.URL, pr.LastCommitSha, err)
 				} else {
+					state.setCIStatus(status)
 					if status == "success" {
+						state.setStatus(envDeleteStatusCIGreen)
+						out.render()
 						if !o.NoMergePullRequest {
 							err = gitProvider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
 							if err != nil {
 								if !logMergeFailure {
 									logMergeFailure = true
-									o.warnf("Failed to merge the Pull Request %s due to %s maybe I don't have karma?\n", pr.URL, err)
+									out.warnf("Failed to merge the Pull Request %s due to %s maybe I don't have karma?\n", pr.URL, err)
 								}
 							}
 						}
@@ -274,6 +453,7 @@ func (o *DeleteAppOptions) waitForGitOpsPullRequest(env *v1.Environment, pullReq
 						return fmt.Errorf("Pull request %s last commit has status %s for ref %s", pr.URL, status, pr.LastCommitSha)
 					}
 				}
+				out.render()
 			}
 			if time.Now().After(end) {
 				return fmt.Errorf("Timed out waiting for pull request %s to merge. Waited %s", pr.URL, duration.String())
@@ -283,3 +463,86 @@ func (o *DeleteAppOptions) waitForGitOpsPullRequest(env *v1.Environment, pullReq
 	}
 	return nil
 }
+
+// waitForResourcesDeleted polls env's namespace until every Deployment, StatefulSet, Service,
+// Ingress and PersistentVolumeClaim labelled with this app's Helm release name is gone, or end
+// is reached. It reports what's still alive each time it polls so a stuck deletion is easy to
+// diagnose from the command output alone.
+func (o *DeleteAppOptions) waitForResourcesDeleted(env *v1.Environment, appName string, end time.Time, out *deleteAppOutput) error {
+	ns := env.Spec.Namespace
+	if ns == "" {
+		return nil
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+
+	out.printf("Waiting for resources of app %s to terminate in namespace %s\n", util.ColorInfo(appName), util.ColorInfo(ns))
+	listOptions := metav1.ListOptions{
+		LabelSelector: helmReleaseLabel + "=" + appName,
+	}
+	for {
+		remaining, err := o.remainingAppResources(client, ns, listOptions)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			out.printf("Resources for app %s have terminated in namespace %s\n", util.ColorInfo(appName), util.ColorInfo(ns))
+			return nil
+		}
+		if time.Now().After(end) {
+			return fmt.Errorf("Timed out waiting for app %s resources to terminate in namespace %s, still remaining: %s", appName, ns, strings.Join(remaining, ", "))
+		}
+		out.printf("Still waiting on: %s\n", strings.Join(remaining, ", "))
+		time.Sleep(deleteAppResourcePollInterval)
+	}
+}
+
+// remainingAppResources returns a human readable "kind/name" entry for every Deployment,
+// StatefulSet, Service, Ingress and PersistentVolumeClaim in ns that still matches listOptions
+func (o *DeleteAppOptions) remainingAppResources(client kubernetes.Interface, ns string, listOptions metav1.ListOptions) ([]string, error) {
+	remaining := []string{}
+
+	deployments, err := client.AppsV1beta1().Deployments(ns).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments.Items {
+		remaining = append(remaining, "Deployment/"+d.Name)
+	}
+
+	statefulSets, err := client.AppsV1beta1().StatefulSets(ns).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statefulSets.Items {
+		remaining = append(remaining, "StatefulSet/"+s.Name)
+	}
+
+	services, err := client.CoreV1().Services(ns).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range services.Items {
+		remaining = append(remaining, "Service/"+s.Name)
+	}
+
+	ingresses, err := client.ExtensionsV1beta1().Ingresses(ns).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range ingresses.Items {
+		remaining = append(remaining, "Ingress/"+i.Name)
+	}
+
+	pvcs, err := client.CoreV1().PersistentVolumeClaims(ns).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pvcs.Items {
+		remaining = append(remaining, "PersistentVolumeClaim/"+p.Name)
+	}
+
+	return remaining, nil
+}
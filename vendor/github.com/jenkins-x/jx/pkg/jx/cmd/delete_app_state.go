@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// envDeleteStatus is a state in the per-Environment app deletion state machine driven by
+// DeleteAppOptions.deleteAppFromEnvironment
+type envDeleteStatus string
+
+const (
+	envDeleteStatusPending         envDeleteStatus = "PENDING"
+	envDeleteStatusPROpen          envDeleteStatus = "PR_OPEN"
+	envDeleteStatusCIGreen         envDeleteStatus = "CI_GREEN"
+	envDeleteStatusMerged          envDeleteStatus = "MERGED"
+	envDeleteStatusVerifiedDeleted envDeleteStatus = "VERIFIED_DELETED"
+	envDeleteStatusFailed          envDeleteStatus = "FAILED"
+)
+
+// envDeleteState tracks one Environment's progress through the delete state machine. It's
+// updated concurrently from the goroutine deleting the app from that Environment and read
+// concurrently by the live table renderer, so every access goes through the mutex
+type envDeleteState struct {
+	Environment    string `json:"environment" yaml:"environment"`
+	Status         envDeleteStatus `json:"status" yaml:"status"`
+	PullRequestURL string `json:"pullRequestURL,omitempty" yaml:"pullRequestURL,omitempty"`
+	CIStatus       string `json:"ciStatus,omitempty" yaml:"ciStatus,omitempty"`
+	Error          string `json:"error,omitempty" yaml:"error,omitempty"`
+	StartTime      time.Time `json:"-" yaml:"-"`
+
+	mutex sync.Mutex
+}
+
+func newEnvDeleteState(envName string) *envDeleteState {
+	return &envDeleteState{
+		Environment: envName,
+		Status:      envDeleteStatusPending,
+		StartTime:   time.Now(),
+	}
+}
+
+func (s *envDeleteState) setStatus(status envDeleteStatus) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Status = status
+}
+
+func (s *envDeleteState) setPullRequest(url string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.PullRequestURL = url
+}
+
+func (s *envDeleteState) setCIStatus(status string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.CIStatus = status
+}
+
+func (s *envDeleteState) setError(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Status = envDeleteStatusFailed
+	s.Error = err.Error()
+}
+
+// snapshot returns a copy of the state safe to read or marshal without holding the mutex
+func (s *envDeleteState) snapshot() envDeleteState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return envDeleteState{
+		Environment:    s.Environment,
+		Status:         s.Status,
+		PullRequestURL: s.PullRequestURL,
+		CIStatus:       s.CIStatus,
+		Error:          s.Error,
+		StartTime:      s.StartTime,
+	}
+}
+
+// DeleteAppReport is the final summary of a `jx delete app` run, printed as JSON/YAML when
+// --output is set to something other than "table" so CI pipelines can consume the result
+type DeleteAppReport struct {
+	App          string            `json:"app" yaml:"app"`
+	Environments []envDeleteState `json:"environments" yaml:"environments"`
+}
+
+// deleteAppOutput serializes every render of the live status table and every log line written
+// while DeleteAppOptions.deleteApp's per-Environment goroutines are running, so two Environments
+// finishing a step at the same moment can't interleave their output or tear the table mid-render
+type deleteAppOutput struct {
+	mutex  sync.Mutex
+	o      *DeleteAppOptions
+	states []*envDeleteState
+}
+
+func newDeleteAppOutput(o *DeleteAppOptions, states []*envDeleteState) *deleteAppOutput {
+	return &deleteAppOutput{o: o, states: states}
+}
+
+func (out *deleteAppOutput) render() {
+	out.mutex.Lock()
+	defer out.mutex.Unlock()
+	out.o.renderDeleteAppTable(out.states)
+}
+
+func (out *deleteAppOutput) printf(format string, a ...interface{}) {
+	out.mutex.Lock()
+	defer out.mutex.Unlock()
+	out.o.Printf(format, a...)
+}
+
+func (out *deleteAppOutput) warnf(format string, a ...interface{}) {
+	out.mutex.Lock()
+	defer out.mutex.Unlock()
+	out.o.warnf(format, a...)
+}
+
+// renderDeleteAppTable prints a kubectl-get-w style table of every Environment's current
+// deletion status, pull request, CI status and elapsed time
+func (o *DeleteAppOptions) renderDeleteAppTable(states []*envDeleteState) {
+	table := o.CreateTable()
+	table.AddRow("ENVIRONMENT", "STATUS", "PULL REQUEST", "CI STATUS", "ELAPSED")
+	for _, s := range states {
+		snap := s.snapshot()
+		elapsed := time.Since(snap.StartTime).Round(time.Second).String()
+		table.AddRow(snap.Environment, string(snap.Status), snap.PullRequestURL, snap.CIStatus, elapsed)
+	}
+	table.Render()
+}
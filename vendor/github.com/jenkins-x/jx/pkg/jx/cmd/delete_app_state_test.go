@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvDeletionWavesWithNoOrder(t *testing.T) {
+	o := &DeleteAppOptions{}
+	waves := o.envDeletionWaves([]string{"staging", "production", "qa"})
+
+	assert.Equal(t, [][]string{{"staging", "production", "qa"}}, waves)
+}
+
+func TestEnvDeletionWavesOrdersNamedEnvsOneAtATimeThenRunsTheRestConcurrently(t *testing.T) {
+	o := &DeleteAppOptions{EnvOrder: "staging, production"}
+	waves := o.envDeletionWaves([]string{"qa", "staging", "production", "uat"})
+
+	assert.Equal(t, [][]string{
+		{"staging"},
+		{"production"},
+		{"qa", "uat"},
+	}, waves)
+}
+
+func TestEnvDeletionWavesIgnoresUnknownOrderedNames(t *testing.T) {
+	o := &DeleteAppOptions{EnvOrder: "staging,does-not-exist"}
+	waves := o.envDeletionWaves([]string{"staging", "production"})
+
+	assert.Equal(t, [][]string{
+		{"staging"},
+		{"production"},
+	}, waves)
+}
+
+func TestEnvDeleteStateConcurrentUpdatesAndSnapshot(t *testing.T) {
+	state := newEnvDeleteState("staging")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			state.setStatus(envDeleteStatusCIGreen)
+		}()
+		go func() {
+			defer wg.Done()
+			state.setPullRequest("https://example.com/pull/1")
+		}()
+		go func() {
+			defer wg.Done()
+			state.setCIStatus("success")
+		}()
+	}
+	wg.Wait()
+
+	snap := state.snapshot()
+	assert.Equal(t, envDeleteStatusCIGreen, snap.Status)
+	assert.Equal(t, "https://example.com/pull/1", snap.PullRequestURL)
+	assert.Equal(t, "success", snap.CIStatus)
+
+	state.setError(errors.New("boom"))
+	snap = state.snapshot()
+	assert.Equal(t, envDeleteStatusFailed, snap.Status)
+	assert.Equal(t, "boom", snap.Error)
+}
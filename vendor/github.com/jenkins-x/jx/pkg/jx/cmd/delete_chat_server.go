@@ -7,6 +7,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -67,6 +68,11 @@ func (o *DeleteChatServerOptions) Run() error {
 	}
 	config := authConfigSvc.Config()
 
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+
 	serverNames := config.GetServerNames()
 	for _, arg := range args {
 		idx := config.IndexOfServerName(arg)
@@ -77,6 +83,11 @@ func (o *DeleteChatServerOptions) Run() error {
 			return util.InvalidArg(arg, serverNames)
 		}
 		config.Servers = append(config.Servers[0:idx], config.Servers[idx+1:]...)
+
+		err = kube.DeleteChatServiceForHost(jxClient, devNs, arg)
+		if err != nil {
+			return err
+		}
 	}
 	err = authConfigSvc.SaveConfig()
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os/user"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/spf13/cobra"
@@ -12,9 +13,15 @@ import (
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+// devPodLastActivityAnnotation is written by the DevPod shell wrapper every time a session is
+// active. `jx gc devpods` uses it to find idle pods that can be safely reaped
+const devPodLastActivityAnnotation = "jenkins-x.io/devpod-last-activity"
+
 var (
 	deleteDevPodLong = templates.LongDesc(`
 		Deletes one or more DevPods
@@ -29,12 +36,26 @@ var (
 
 		# delete a specific DevPod
 		jx delete devpod myuser-maven2
+
+		# delete all of your own DevPods
+		jx delete devpod --all
+
+		# delete all DevPods matching a label selector
+		jx delete devpod --selector jenkins.io/devpod-template=go
+
+		# delete DevPods that have been running for more than a day
+		jx delete devpod --all --older-than 24h
 	`)
 )
 
 // DeleteDevPodOptions are the flags for delete commands
 type DeleteDevPodOptions struct {
 	CommonOptions
+
+	All       bool
+	User      string
+	Selector  string
+	OlderThan string
 }
 
 // NewCmdDeleteDevPod creates a command object for the generic "get" action, which
@@ -62,6 +83,11 @@ func NewCmdDeleteDevPod(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cob
 		},
 	}
 
+	cmd.Flags().BoolVarP(&options.All, "all", "a", false, "Delete every DevPod owned by the current user")
+	cmd.Flags().StringVarP(&options.User, "user", "u", "", "Delete DevPods owned by the given user instead of the current user. Requires admin RBAC on the dev namespace")
+	cmd.Flags().StringVarP(&options.Selector, "selector", "l", "", "Delete DevPods matching this label selector")
+	cmd.Flags().StringVarP(&options.OlderThan, "older-than", "", "", "Only delete DevPods created more than this duration ago, e.g. 24h")
+
 	return cmd
 }
 
@@ -77,42 +103,131 @@ func (o *DeleteDevPodOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	u, err := user.Current()
+
+	username, err := o.devPodUsername()
 	if err != nil {
 		return err
 	}
 
-	username := u.Username
-	names, err := kube.GetPodNames(client, ns, username)
+	pods, err := o.findDevPods(client, ns, username)
 	if err != nil {
 		return err
 	}
 
 	info := util.ColorInfo
-	if len(names) == 0 {
+	if len(pods) == 0 {
 		return fmt.Errorf("There are no DevPods for user %s in namespace %s. You can create one via: %s\n", info(username), info(ns), info("jx create devpod"))
 	}
 
-	if len(args) == 0 {
+	names := podNames(pods)
+	if len(args) == 0 && !o.All && o.Selector == "" && o.OlderThan == "" {
 		args, err = util.PickNames(names, "Pick DevPod:")
 		if err != nil {
 			return err
 		}
+	} else if len(args) == 0 {
+		args = names
+	} else {
+		for _, arg := range args {
+			if util.StringArrayIndex(names, arg) < 0 {
+				return util.InvalidOption(optionLabel, arg, names)
+			}
+		}
 	}
-	deletePods := strings.Join(args, ", ")
 
-	if !util.Confirm("You are about to delete the DevPods: "+deletePods, false, "The list of DevPods names to be deleted") {
+	args, err = o.filterOlderThan(pods, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		o.Printf("No DevPods matched the given criteria\n")
 		return nil
 	}
-	for _, name := range args {
-		if util.StringArrayIndex(names, name) < 0 {
-			return util.InvalidOption(optionLabel, name, names)
+
+	return o.deletePods(client, ns, args)
+}
+
+func (o *DeleteDevPodOptions) devPodUsername() (string, error) {
+	if o.User != "" {
+		return o.User, nil
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+// findDevPods returns the candidate DevPods for this invocation, applying the --selector filter
+// up front, or the username filter when no --selector is given
+func (o *DeleteDevPodOptions) findDevPods(client kubernetes.Interface, ns string, username string) ([]corev1.Pod, error) {
+	listOptions := metav1.ListOptions{}
+	if o.Selector != "" {
+		listOptions.LabelSelector = o.Selector
+	} else {
+		listOptions.LabelSelector = kube.LabelDevPodUsername + "=" + username
+	}
+	podList, err := client.CoreV1().Pods(ns).List(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+func podNames(pods []corev1.Pod) []string {
+	names := []string{}
+	for _, p := range pods {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// filterOlderThan narrows the candidate pod names down to those created more than OlderThan ago
+func (o *DeleteDevPodOptions) filterOlderThan(pods []corev1.Pod, names []string) ([]string, error) {
+	if o.OlderThan == "" {
+		return names, nil
+	}
+	duration, err := time.ParseDuration(o.OlderThan)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid duration format %s for option --older-than: %s", o.OlderThan, err)
+	}
+	cutoff := time.Now().Add(-duration)
+
+	podsByName := map[string]*corev1.Pod{}
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+
+	filtered := []string{}
+	for _, name := range names {
+		pod := podsByName[name]
+		if pod != nil && pod.CreationTimestamp.Time.Before(cutoff) {
+			filtered = append(filtered, name)
 		}
-		err = client.CoreV1().Pods(ns).Delete(name, &metav1.DeleteOptions{})
+	}
+	return filtered, nil
+}
+
+func (o *DeleteDevPodOptions) deletePods(client kubernetes.Interface, ns string, names []string) error {
+	deletePods := strings.Join(names, ", ")
+
+	if !o.BatchMode {
+		if !util.Confirm("You are about to delete the DevPods: "+deletePods, false, "The list of DevPods names to be deleted") {
+			return nil
+		}
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME")
+	for _, name := range names {
+		err := client.CoreV1().Pods(ns).Delete(name, &metav1.DeleteOptions{})
 		if err != nil {
 			return err
 		}
+		table.AddRow(name)
 	}
+	table.Render()
+
 	o.Printf("Deleted DevPods %s\n", util.ColorInfo(deletePods))
 	return nil
 }
@@ -56,6 +56,7 @@ func NewCmdDeleteTokenAddon(f cmdutil.Factory, out io.Writer, errOut io.Writer)
 	}
 	options.ServerFlags.addGitServerFlags(cmd)
 	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "The kind of addon. Defaults to the addon name if not specified")
+	addOutputFlag(cmd, &options.Output)
 	return cmd
 }
 
@@ -85,14 +86,17 @@ func (o *DeleteTokenAddonOptions) Run() error {
 	for _, username := range args {
 		err = server.DeleteUser(username)
 		if err != nil {
+			o.Emit(InstallEvent{Phase: "deleted", Error: err.Error()})
 			return err
 		}
 	}
 	err = authConfigSvc.SaveConfig()
 	if err != nil {
+		o.Emit(InstallEvent{Phase: "deleted", Error: err.Error()})
 		return err
 	}
 	o.Printf("Deleted API tokens for users: %s for addon server %s at %s from local settings\n",
 		util.ColorInfo(strings.Join(args, ", ")), util.ColorInfo(server.Name), util.ColorInfo(server.URL))
+	o.Emit(InstallEvent{Phase: "deleted", Tool: server.Name})
 	return nil
 }
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	devPodLong = templates.LongDesc(`
+		Manage running DevPods.
+`)
+)
+
+// DevPodOptions contains the command line flags shared by all "jx devpod" sub-commands
+type DevPodOptions struct {
+	CommonOptions
+}
+
+// NewCmdDevPod creates the "devpod" command object
+func NewCmdDevPod(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DevPodOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "devpod",
+		Short: "Manage running DevPods",
+		Long:  devPodLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdDevPodProxy(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *DevPodOptions) Run() error {
+	return o.Cmd.Help()
+}
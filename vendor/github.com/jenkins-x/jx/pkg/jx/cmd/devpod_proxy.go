@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"os/signal"
+	"os/user"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	optionBindAddress = "bind-address"
+	optionHTTPPort    = "http-port"
+
+	devPodProxyReconnectBackoffMin = 1 * time.Second
+	devPodProxyReconnectBackoffMax = 30 * time.Second
+)
+
+var (
+	devPodProxyLong = templates.LongDesc(`
+		Opens local port-forwards to every container port advertised by a DevPod and keeps them
+		alive for the life of the session.
+
+		A small JSON endpoint is served alongside the forwarded ports listing the
+		{containerPort, localPort, scheme} of each one, so tools can discover stable localhost
+		URLs instead of re-running 'kubectl port-forward' every time a web server or debugger is
+		started inside the DevPod. If a forward disconnects, e.g. because the DevPod was
+		rescheduled, it's reconnected automatically rather than ending the session.
+`)
+
+	devPodProxyExample = templates.Examples(`
+		# proxies the ports of your own DevPod, picking one if you have more than one
+		jx devpod proxy
+
+		# proxies the ports of a specific DevPod
+		jx devpod proxy myuser-maven2
+	`)
+)
+
+// DevPodProxyOptions are the flags for the "devpod proxy" command
+type DevPodProxyOptions struct {
+	CommonOptions
+
+	BindAddress string
+	HTTPPort    int
+}
+
+// NewCmdDevPodProxy creates the "devpod proxy" command object
+func NewCmdDevPodProxy(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DevPodProxyOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "proxy [devpod name]",
+		Short:   "Proxies the container ports of a DevPod to localhost",
+		Long:    devPodProxyLong,
+		Example: devPodProxyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.BindAddress, optionBindAddress, "", "127.0.0.1", "The local address to bind the forwarded ports and session endpoint to")
+	cmd.Flags().IntVarP(&options.HTTPPort, optionHTTPPort, "", 0, "The local port to serve the session's JSON endpoint on. Defaults to a random free port")
+	return cmd
+}
+
+// Run implements this command
+func (o *DevPodProxyOptions) Run() error {
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := o.Factory.CreateClientConfig()
+	if err != nil {
+		return err
+	}
+
+	podName, err := o.resolveDevPodName(client, ns)
+	if err != nil {
+		return err
+	}
+	pod, err := client.CoreV1().Pods(ns).Get(podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find DevPod %s in namespace %s: %s", podName, ns, err)
+	}
+
+	return runDevPodProxy(&o.CommonOptions, restConfig, ns, pod, o.BindAddress, o.HTTPPort)
+}
+
+// resolveDevPodName returns the DevPod named by the command's sole argument, or - if none was
+// given - the current user's only DevPod, prompting if they have more than one
+func (o *DevPodProxyOptions) resolveDevPodName(client kubernetes.Interface, ns string) (string, error) {
+	if len(o.Args) > 0 {
+		return o.Args[0], nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	names, _, err := kube.GetDevPodNames(client, ns, u.Username)
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("There are no DevPods for user %s in namespace %s. You can create one via: %s\n", u.Username, ns, util.ColorInfo("jx create devpod"))
+	}
+	if len(names) == 1 {
+		return names[0], nil
+	}
+	return util.PickName(names, "Pick DevPod to proxy: ")
+}
+
+// devPodProxyTarget describes one forwarded container port, as advertised over the proxy's JSON
+// endpoint
+type devPodProxyTarget struct {
+	ContainerPort int32  `json:"containerPort"`
+	LocalPort     int    `json:"localPort"`
+	Scheme        string `json:"scheme"`
+}
+
+// runDevPodProxy discovers the advertised container ports on pod, opens a local port-forward for
+// each one and serves a JSON listing of them at http://bindAddress:httpPort/targets. Modelled on
+// the session-proxy pattern in the gitlab-runner Kubernetes executor: one controller goroutine
+// per forwarded port owns its stream for the life of the session, and a dropped stream is
+// reconnected with a bounded backoff rather than tearing the whole session down.
+func runDevPodProxy(o *CommonOptions, restConfig *rest.Config, ns string, pod *corev1.Pod, bindAddress string, httpPort int) error {
+	targets := devPodProxyTargets(pod)
+	if len(targets) == 0 {
+		return fmt.Errorf("Pod %s does not advertise any container ports to proxy", pod.Name)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(bindAddress, strconv.Itoa(httpPort)))
+	if err != nil {
+		return fmt.Errorf("Failed to start DevPod proxy session endpoint: %s", err)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(target *devPodProxyTarget) {
+			defer wg.Done()
+			reconnectDevPodPortForward(o, restConfig, ns, pod.Name, target, stop)
+		}(&targets[i])
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/targets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(targets)
+	})
+	server := &http.Server{Handler: mux}
+
+	o.Printf("DevPod proxy for %s listening on %s - forwarded ports:\n", util.ColorInfo(pod.Name), util.ColorInfo(listener.Addr().String()))
+	for _, t := range targets {
+		o.Printf("  %s\n", util.ColorInfo(fmt.Sprintf("%s://%s -> container port %d", t.Scheme, net.JoinHostPort(bindAddress, strconv.Itoa(t.LocalPort)), t.ContainerPort)))
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Serve(listener)
+	}()
+
+	select {
+	case <-sigCh:
+		o.Printf("Stopping DevPod proxy\n")
+	case err := <-serverErr:
+		if err != nil && err != http.ErrServerClosed {
+			close(stop)
+			wg.Wait()
+			return err
+		}
+	}
+
+	server.Close()
+	close(stop)
+	wg.Wait()
+	return nil
+}
+
+// devPodProxyTargets builds the list of forwardable targets from the container ports advertised
+// on pod's spec, keyed to the container port number so forwarded URLs stay stable across restarts
+func devPodProxyTargets(pod *corev1.Pod) []devPodProxyTarget {
+	targets := []devPodProxyTarget{}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.ContainerPort == 0 {
+				continue
+			}
+			targets = append(targets, devPodProxyTarget{
+				ContainerPort: p.ContainerPort,
+				LocalPort:     int(p.ContainerPort),
+				Scheme:        devPodProxyScheme(p),
+			})
+		}
+	}
+	return targets
+}
+
+// devPodProxyScheme guesses the scheme to advertise for a container port from its name, following
+// the same 'name contains protocol hint' convention used by Kubernetes Service port naming
+func devPodProxyScheme(p corev1.ContainerPort) string {
+	if strings.Contains(strings.ToLower(p.Name), "https") {
+		return "https"
+	}
+	return "http"
+}
+
+// reconnectDevPodPortForward keeps target's port forwarded for the lifetime of the session,
+// restarting the stream with a bounded backoff whenever it drops instead of ending the session
+func reconnectDevPodPortForward(o *CommonOptions, restConfig *rest.Config, ns string, podName string, target *devPodProxyTarget, stop <-chan struct{}) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = devPodProxyReconnectBackoffMin
+	b.MaxInterval = devPodProxyReconnectBackoffMax
+	b.MaxElapsedTime = 0
+
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		err := forwardDevPodPort(restConfig, ns, podName, target, stop)
+		if err == nil {
+			return
+		}
+		o.Printf(util.ColorWarning("DevPod proxy: port forward for container port %d disconnected: %s - reconnecting\n"), target.ContainerPort, err)
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(b.NextBackOff()):
+		}
+	}
+}
+
+// forwardDevPodPort opens a single SPDY port-forward stream from target.LocalPort to
+// target.ContainerPort on podName and blocks until it ends - returning nil if stop was closed, or
+// the stream's error otherwise so the caller can reconnect
+func forwardDevPodPort(restConfig *rest.Config, ns string, podName string, target *devPodProxyTarget, stop <-chan struct{}) error {
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return err
+	}
+	u, err := neturl.Parse(restConfig.Host)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, "api", "v1", "namespaces", ns, "pods", podName, "portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", u)
+
+	ports := []string{fmt.Sprintf("%d:%d", target.LocalPort, target.ContainerPort)}
+	ready := make(chan struct{})
+	forwardStop := make(chan struct{})
+	errCh := make(chan error, 1)
+
+	fw, err := portforward.New(dialer, ports, forwardStop, ready, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return err
+	}
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-stop:
+		close(forwardStop)
+		<-errCh
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportLong = templates.LongDesc(`
+		Exports resources as portable Kubernetes YAML manifests, suitable for checking into a
+		repository and re-applying elsewhere.
+`)
+)
+
+// ExportOptions contains the command line flags shared by all "jx export" sub-commands
+type ExportOptions struct {
+	CommonOptions
+}
+
+// NewCmdExport creates the "export" command object
+func NewCmdExport(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ExportOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports resources as portable Kubernetes YAML",
+		Long:  exportLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdExportDevPod(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *ExportOptions) Run() error {
+	return o.Cmd.Help()
+}
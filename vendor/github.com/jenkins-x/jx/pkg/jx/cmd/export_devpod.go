@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// devPodExportVolumes are the Jenkins-specific volumes/volume mounts stripped out of a DevPod
+// when it's exported, since they only make sense inside the build cluster and would fail to
+// mount if the manifest were re-applied elsewhere
+var devPodExportVolumes = map[string]bool{
+	"workspace-volume":   true,
+	"docker-sock":        true,
+	"jenkins-docker-cfg": true,
+}
+
+var (
+	exportDevPodLong = templates.LongDesc(`
+		Exports a DevPod as a sanitized, portable Pod YAML manifest.
+
+		Server-populated fields, runtime status and Jenkins-specific volume mounts are stripped out
+		so the result can be checked into a repository and re-created elsewhere via
+		'jx create devpod --from-file'.
+`)
+
+	exportDevPodExample = templates.Examples(`
+		# export a DevPod as YAML to stdout
+		jx export devpod mypod
+
+		# export a DevPod as YAML to a file
+		jx export devpod mypod -f mypod.yaml
+	`)
+)
+
+// ExportDevPodOptions the command line options for "jx export devpod"
+type ExportDevPodOptions struct {
+	ExportOptions
+
+	OutFile string
+}
+
+// NewCmdExportDevPod creates the "export devpod" command object
+func NewCmdExportDevPod(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ExportDevPodOptions{
+		ExportOptions: ExportOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "devpod NAME",
+		Short:   "Exports a DevPod as a portable Pod YAML manifest",
+		Long:    exportDevPodLong,
+		Example: exportDevPodExample,
+		Aliases: []string{"buildpod"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.OutFile, "file", "f", "", "The file to write the Pod YAML to instead of stdout")
+	return cmd
+}
+
+// Run implements this command
+func (o *ExportDevPodOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Must specify the name of the DevPod to export")
+	}
+	name := o.Args[0]
+
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+
+	pod, err := client.CoreV1().Pods(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Failed to find DevPod %s in namespace %s: %s", name, ns, err)
+	}
+
+	data, err := yaml.Marshal(sanitizeDevPodForExport(pod))
+	if err != nil {
+		return err
+	}
+
+	if o.OutFile != "" {
+		err = ioutil.WriteFile(o.OutFile, data, util.DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		o.Printf("Exported DevPod %s to %s\n", util.ColorInfo(name), util.ColorInfo(o.OutFile))
+		return nil
+	}
+	o.Printf("%s", string(data))
+	return nil
+}
+
+// sanitizeDevPodForExport strips the server-populated metadata, runtime status and
+// Jenkins-specific volume mounts from pod so the result is a portable manifest that can be
+// checked into a repo and re-applied with 'jx create devpod --from-file'
+func sanitizeDevPodForExport(pod *corev1.Pod) *corev1.Pod {
+	sanitized := pod.DeepCopy()
+
+	sanitized.ObjectMeta = metav1.ObjectMeta{
+		Name:        pod.Name,
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+	}
+	delete(sanitized.Labels, kube.LabelDevPodName)
+	delete(sanitized.Labels, kube.LabelDevPodUsername)
+	delete(sanitized.Annotations, kube.AnnotationWorkingDir)
+
+	sanitized.Status = corev1.PodStatus{}
+	sanitized.Spec.NodeName = ""
+	sanitized.Spec.ServiceAccountName = ""
+	sanitized.Spec.DeprecatedServiceAccount = ""
+
+	volumes := []corev1.Volume{}
+	for _, v := range sanitized.Spec.Volumes {
+		if !devPodExportVolumes[v.Name] {
+			volumes = append(volumes, v)
+		}
+	}
+	sanitized.Spec.Volumes = volumes
+
+	containers := []corev1.Container{}
+	for _, c := range sanitized.Spec.Containers {
+		mounts := []corev1.VolumeMount{}
+		for _, m := range c.VolumeMounts {
+			if !devPodExportVolumes[m.Name] {
+				mounts = append(mounts, m)
+			}
+		}
+		c.VolumeMounts = mounts
+		containers = append(containers, c)
+	}
+	sanitized.Spec.Containers = containers
+
+	return sanitized
+}
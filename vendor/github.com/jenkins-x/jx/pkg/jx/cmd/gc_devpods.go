@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	gcDevPodsLong = templates.LongDesc(`
+		Garbage collects DevPods which have been idle for longer than a given duration
+
+		A DevPod is considered idle once it has gone longer than the --older-than duration without its
+		` + "`" + devPodLastActivityAnnotation + "`" + ` annotation being refreshed.
+
+		For more documentation see: [http://jenkins-x.io/developing/devpods/](http://jenkins-x.io/developing/devpods/)
+`)
+
+	gcDevPodsExample = templates.Examples(`
+		# garbage collect DevPods which have been idle for more than 2 hours
+		jx gc devpods
+
+		# use a custom idle duration
+		jx gc devpods --older-than 30m
+	`)
+)
+
+// GCDevPodsOptions containers the CLI options
+type GCDevPodsOptions struct {
+	CommonOptions
+
+	OlderThan string
+}
+
+// NewCmdGCDevPods creates the command object
+func NewCmdGCDevPods(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GCDevPodsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "devpods",
+		Short:   "Garbage collect idle DevPods",
+		Long:    gcDevPodsLong,
+		Example: gcDevPodsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.OlderThan, "older-than", "", "2h", "The idle duration, based on the last recorded activity, after which a DevPod is reaped")
+
+	return cmd
+}
+
+// Run implements this command
+func (o *GCDevPodsOptions) Run() error {
+	duration, err := time.ParseDuration(o.OlderThan)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --older-than: %s", o.OlderThan, err)
+	}
+
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+
+	podList, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{
+		LabelSelector: kube.LabelDevPodUsername + "," + kube.LabelPodTemplate,
+	})
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-duration)
+	reaped := []string{}
+	for _, pod := range podList.Items {
+		lastActivity := pod.CreationTimestamp.Time
+		if value := pod.Annotations[devPodLastActivityAnnotation]; value != "" {
+			if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+				lastActivity = parsed
+			}
+		}
+		if lastActivity.Before(cutoff) {
+			err := client.CoreV1().Pods(ns).Delete(pod.Name, &metav1.DeleteOptions{})
+			if err != nil {
+				return err
+			}
+			reaped = append(reaped, pod.Name)
+		}
+	}
+
+	if len(reaped) == 0 {
+		o.Printf("No idle DevPods found older than %s\n", o.OlderThan)
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME")
+	for _, name := range reaped {
+		table.AddRow(name)
+	}
+	table.Render()
+
+	o.Printf("Garbage collected %s idle DevPods\n", util.ColorInfo(len(reaped)))
+	return nil
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/atotto/clipboard"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const defaultAdminSecretsFileName = "adminSecrets.yaml"
+
+// GetCredentialsOptions the command line options
+type GetCredentialsOptions struct {
+	GetOptions
+
+	Namespace string
+	File      string
+
+	Jenkins          bool
+	ChartMuseum      bool
+	Grafana          bool
+	Nexus            bool
+	IngressBasicAuth bool
+	All              bool
+	Reveal           bool
+	Copy             bool
+}
+
+var (
+	getCredentialsLong = templates.LongDesc(`
+		Displays the admin credentials generated by 'jx install' for the Jenkins, ChartMuseum, Grafana and Nexus services
+
+		Passing one of the --jenkins, --chartmuseum, --grafana, --nexus or --ingress-basic-auth flags prints just that
+		single password and, when --copy is enabled, copies it to the clipboard.
+`)
+
+	getCredentialsExample = templates.Examples(`
+		# Displays all the generated credentials in a table
+		jx get credentials --all
+
+		# Copies the Jenkins admin password to the clipboard
+		jx get credentials --jenkins --copy
+
+		# Reveals the Nexus admin password
+		jx get credentials --nexus --reveal
+	`)
+)
+
+// NewCmdGetCredentials creates the command
+func NewCmdGetCredentials(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetCredentialsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "credentials [flags]",
+		Short:   "Display the admin credentials generated during the install",
+		Long:    getCredentialsLong,
+		Example: getCredentialsExample,
+		Aliases: []string{"credential", "creds", "secrets"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "Not used: the credentials are always read from the admin secrets YAML given by --file")
+	cmd.Flags().StringVarP(&options.File, "file", "f", "", "The admin secrets YAML file to read. Defaults to the adminSecrets.yaml generated by 'jx install'")
+	cmd.Flags().BoolVarP(&options.Jenkins, "jenkins", "", false, "Display the Jenkins admin password")
+	cmd.Flags().BoolVarP(&options.ChartMuseum, "chartmuseum", "", false, "Display the ChartMuseum admin password")
+	cmd.Flags().BoolVarP(&options.Grafana, "grafana", "", false, "Display the Grafana admin password")
+	cmd.Flags().BoolVarP(&options.Nexus, "nexus", "", false, "Display the Nexus admin password")
+	cmd.Flags().BoolVarP(&options.IngressBasicAuth, "ingress-basic-auth", "", false, "Display the ingress basic auth credential")
+	cmd.Flags().BoolVarP(&options.All, "all", "a", false, "Display all the credentials in a table")
+	cmd.Flags().BoolVarP(&options.Reveal, "reveal", "", false, "Reveal the password in plain text instead of masking it")
+	cmd.Flags().BoolVarP(&options.Copy, "copy", "", false, "Copy the single requested password to the clipboard instead of printing it")
+
+	return cmd
+}
+
+// Run implements this command
+func (o *GetCredentialsOptions) Run() error {
+	adminSecretsService := &config.AdminSecretsService{
+		FileName: o.adminSecretsFileName(),
+	}
+	err := adminSecretsService.LoadConfig()
+	if err != nil {
+		return err
+	}
+	secrets := adminSecretsService.Secrets
+
+	if o.All {
+		return o.renderAll(secrets)
+	}
+
+	name, password, err := o.pickSinglePassword(secrets)
+	if err != nil {
+		return err
+	}
+	if o.Copy {
+		err = clipboard.WriteAll(password)
+		if err != nil {
+			return fmt.Errorf("failed to copy %s password to the clipboard: %v", name, err)
+		}
+		o.Printf("Copied the %s password to the clipboard\n", util.ColorInfo(name))
+		return nil
+	}
+	o.Printf("%s\n", o.maybeMask(password))
+	return nil
+}
+
+func (o *GetCredentialsOptions) adminSecretsFileName() string {
+	if o.File != "" {
+		return o.File
+	}
+	return filepath.Join(".jx", defaultAdminSecretsFileName)
+}
+
+func (o *GetCredentialsOptions) pickSinglePassword(secrets config.AdminSecretsConfig) (string, string, error) {
+	switch {
+	case o.Jenkins:
+		return "Jenkins", secrets.Jenkins.JenkinsSecret.Password, nil
+	case o.ChartMuseum:
+		return "ChartMuseum", secrets.ChartMuseum.ChartMuseumSecret.Password, nil
+	case o.Grafana:
+		return "Grafana", secrets.Grafana.GrafanaSecret.Password, nil
+	case o.Nexus:
+		return "Nexus", secrets.Nexus.DefaultAdminPassword, nil
+	case o.IngressBasicAuth:
+		return "Ingress basic auth", secrets.IngressBasicAuth, nil
+	default:
+		return "", "", fmt.Errorf("no credential selected, pass one of --jenkins, --chartmuseum, --grafana, --nexus, --ingress-basic-auth or --all")
+	}
+}
+
+func (o *GetCredentialsOptions) renderAll(secrets config.AdminSecretsConfig) error {
+	table := o.CreateTable()
+	table.AddRow("SERVICE", "USERNAME", "PASSWORD")
+	table.AddRow("Jenkins", "admin", o.maybeMask(secrets.Jenkins.JenkinsSecret.Password))
+	table.AddRow("ChartMuseum", secrets.ChartMuseum.ChartMuseumSecret.User, o.maybeMask(secrets.ChartMuseum.ChartMuseumSecret.Password))
+	table.AddRow("Grafana", secrets.Grafana.GrafanaSecret.User, o.maybeMask(secrets.Grafana.GrafanaSecret.Password))
+	table.AddRow("Nexus", "admin", o.maybeMask(secrets.Nexus.DefaultAdminPassword))
+	table.AddRow("Ingress basic auth", "admin", o.maybeMask(secrets.IngressBasicAuth))
+	table.Render()
+	return nil
+}
+
+func (o *GetCredentialsOptions) maybeMask(password string) string {
+	if o.Reveal || password == "" {
+		return password
+	}
+	return "****************"
+}
@@ -23,6 +23,7 @@ type GetCVEOptions struct {
 	Version           string
 	Env               string
 	VulnerabilityType string
+	Provider          string
 }
 
 var (
@@ -39,6 +40,9 @@ var (
 		jx get cve --app foo --version 1.0.0
 		jx get cve --app foo --env staging
 		jx get cve --environment staging
+
+		# Use the Trivy provider instead of the default Anchore one
+		jx get cve --provider trivy --image-name foo
 	`)
 )
 
@@ -79,6 +83,7 @@ func (o *GetCVEOptions) addGetCVEFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ImageID, "image-id", "", "", "Image ID in CVE engine if already known")
 	cmd.Flags().StringVarP(&o.Version, "version", "", "", "Version or tag e.g. 0.0.1")
 	cmd.Flags().StringVarP(&o.Env, "environment", "e", "", "The Environment to find running applications")
+	cmd.Flags().StringVarP(&o.Provider, "provider", "", "", "The CVE provider to query. Possible values: anchore, trivy, grype. Defaults to whichever provider 'jx create addon' registered")
 }
 
 // Run implements this command
@@ -94,10 +99,24 @@ func (o *GetCVEOptions) Run() error {
 		return fmt.Errorf("cannot create jx client: %v", err)
 	}
 
+	server, auth, err := o.CommonOptions.getAddonAuthByKind(kube.ValueKindCVE)
+	if err != nil {
+		return fmt.Errorf("error getting anchore engine auth details, %v", err)
+	}
+
+	// default to whichever provider was registered when the addon was installed, so users don't
+	// have to pass --provider once they've picked a CVE scanner
+	if o.Provider == "" {
+		o.Provider = server.Name
+	}
+	if o.Provider == "" {
+		o.Provider = cve.KindAnchore
+	}
+
 	err = o.ensureCVEServiceAvailable()
 	if err != nil {
-		log.Warnf("no CVE provider service found, are you in your teams dev environment?  Type `jx env` to switch.\n")
-		return fmt.Errorf("if no CVE provider running, try running `jx create addon anchore` in your teams dev environment: %v", err)
+		log.Warnf("no %s CVE provider service found, are you in your teams dev environment?  Type `jx env` to switch.\n", o.Provider)
+		return fmt.Errorf("if no CVE provider running, try running `jx create addon %s` in your teams dev environment: %v", o.Provider, err)
 	}
 
 	// if no flags are set try and guess the image name from the current directory
@@ -105,17 +124,10 @@ func (o *GetCVEOptions) Run() error {
 		return fmt.Errorf("no --image-name, --image-id or --env flags set\n")
 	}
 
-	server, auth, err := o.CommonOptions.getAddonAuthByKind(kube.ValueKindCVE)
-	if err != nil {
-		return fmt.Errorf("error getting anchore engine auth details, %v", err)
-	}
-
-	p, err := cve.NewAnchoreProvider(server, auth)
+	p, err := cve.NewProvider(o.Provider, server, auth)
 	if err != nil {
-		return fmt.Errorf("error creating anchore provider, %v", err)
+		return fmt.Errorf("error creating %s CVE provider, %v", o.Provider, err)
 	}
-	table := o.CreateTable()
-	table.AddRow("Image", util.ColorInfo("Severity"), "Vulnerability", "URL", "Package", "Fix")
 
 	query := cve.CVEQuery{
 		ImageID:     o.ImageID,
@@ -132,6 +144,13 @@ func (o *GetCVEOptions) Run() error {
 		query.TargetNamespace = targetNamespace
 	}
 
+	if !p.SupportsQuery(query) {
+		return fmt.Errorf("the %s CVE provider does not support this query", o.Provider)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("Image", util.ColorInfo("Severity"), "Vulnerability", "URL", "Package", "Fix")
+
 	err = p.GetImageVulnerabilityTable(jxClient, o.kubeClient, &table, query)
 	if err != nil {
 		return fmt.Errorf("error getting vulnerability table for image %s: %v", query.ImageID, err)
@@ -142,7 +161,11 @@ func (o *GetCVEOptions) Run() error {
 }
 
 func (o *GetCVEOptions) ensureCVEServiceAvailable() error {
-	present, err := kube.IsServicePresent(o.kubeClient, anchoreServiceName, o.currentNamespace)
+	serviceName, err := o.cveServiceName()
+	if err != nil {
+		return err
+	}
+	present, err := kube.IsServicePresent(o.kubeClient, serviceName, o.currentNamespace)
 	if err != nil {
 		return fmt.Errorf("no CVE provider service found, are you in your teams dev environment?  Type `jx env` to switch.")
 	}
@@ -153,3 +176,17 @@ func (o *GetCVEOptions) ensureCVEServiceAvailable() error {
 	// todo ask if user wants to intall a CVE provider addon?
 	return nil
 }
+
+// cveServiceName returns the name of the in-cluster service that backs the selected CVE provider
+func (o *GetCVEOptions) cveServiceName() (string, error) {
+	switch o.Provider {
+	case "", cve.KindAnchore:
+		return anchoreServiceName, nil
+	case cve.KindTrivy:
+		return cve.TrivyServiceName, nil
+	case cve.KindGrype:
+		return cve.GrypeServiceName, nil
+	default:
+		return "", util.InvalidOption("provider", o.Provider, []string{cve.KindAnchore, cve.KindTrivy, cve.KindGrype})
+	}
+}
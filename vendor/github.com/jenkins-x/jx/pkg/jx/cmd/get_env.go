@@ -3,15 +3,21 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 )
 
 // GetEnvOptions containers the CLI options
@@ -19,6 +25,14 @@ type GetEnvOptions struct {
 	GetOptions
 
 	PromotionStrategy string
+	Watch             bool
+}
+
+// envDeploymentState is the latest known state of one environment's deployments, kept up to
+// date by a background watch so the table can be redrawn incrementally
+type envDeploymentState struct {
+	env         v1.Environment
+	deployments map[string]appsv1beta1.Deployment
 }
 
 var (
@@ -63,6 +77,7 @@ func NewCmdGetEnv(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	options.addGetFlags(cmd)
 
 	cmd.Flags().StringVarP(&options.PromotionStrategy, "promote", "p", "", "Filters the environments by promotion strategy. Possible values: "+strings.Join(v1.PromotionStrategyTypeValues, ", "))
+	cmd.Flags().BoolVarP(&options.Watch, "watch", "w", false, "Watch the environments and their deployments, redrawing the table as they change")
 
 	return cmd
 }
@@ -91,6 +106,10 @@ func (o *GetEnvOptions) Run() error {
 		return err
 	}
 
+	if o.Watch {
+		return o.watchEnvironments(client, kubeClient, ns)
+	}
+
 	args := o.Args
 	if len(args) > 0 {
 		e := args[0]
@@ -143,22 +162,106 @@ func (o *GetEnvOptions) Run() error {
 		environments := o.filterEnvironments(envs.Items)
 		kube.SortEnvironments(environments)
 
-		if o.Output != "" {
+		wide := o.Output == "wide"
+		if o.Output != "" && !wide {
 			envs.Items = environments
 			return o.renderResult(envs, o.Output)
 		}
 		table := o.CreateTable()
-		table.AddRow("NAME", "LABEL", "KIND", "PROMOTE", "NAMESPACE", "ORDER", "CLUSTER", "SOURCE", "REF", "PR")
+		if wide {
+			table.AddRow("NAME", "LABEL", "KIND", "PROMOTE", "NAMESPACE", "ORDER", "CLUSTER", "SOURCE", "REF", "PR", "STATUS", "VERSION")
+		} else {
+			table.AddRow("NAME", "LABEL", "KIND", "PROMOTE", "NAMESPACE", "ORDER", "CLUSTER", "SOURCE", "REF", "PR")
+		}
 
 		for _, env := range environments {
 			spec := &env.Spec
-			table.AddRow(env.Name, spec.Label, kindString(spec), string(spec.PromotionStrategy), spec.Namespace, util.Int32ToA(spec.Order), spec.Cluster, spec.Source.URL, spec.Source.Ref, spec.PullRequestURL)
+			if wide {
+				status, version := o.latestAppStatusAndVersion(client, kubeClient, &env)
+				table.AddRow(env.Name, spec.Label, kindString(spec), string(spec.PromotionStrategy), spec.Namespace, util.Int32ToA(spec.Order), spec.Cluster, spec.Source.URL, spec.Source.Ref, spec.PullRequestURL, status, version)
+			} else {
+				table.AddRow(env.Name, spec.Label, kindString(spec), string(spec.PromotionStrategy), spec.Namespace, util.Int32ToA(spec.Order), spec.Cluster, spec.Source.URL, spec.Source.Ref, spec.PullRequestURL)
+			}
 		}
 		table.Render()
 	}
 	return nil
 }
 
+// latestAppStatusAndVersion returns the aggregated deployment readiness and the version of the
+// most recently built app in the environment's namespace, for the -o wide view. Either value
+// may be "-" if the environment has no namespace yet or no PipelineActivity can be matched.
+func (o *GetEnvOptions) latestAppStatusAndVersion(jxClient versioned.Interface, kubeClient kubernetes.Interface, env *v1.Environment) (string, string) {
+	ns := env.Spec.Namespace
+	if ns == "" {
+		return "-", "-"
+	}
+	deps, err := kubeClient.AppsV1beta1().Deployments(ns).List(metav1.ListOptions{})
+	if err != nil || len(deps.Items) == 0 {
+		return "-", "-"
+	}
+	status := colorDeploymentHealth(aggregateDeploymentHealth(deps.Items))
+
+	version := "-"
+	var latest *v1.PipelineActivity
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err == nil {
+		for i := range activities.Items {
+			a := &activities.Items[i]
+			if latest == nil || a.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+				latest = a
+			}
+		}
+	}
+	if latest != nil {
+		version = latest.Spec.Version
+		if version == "" {
+			version = "-"
+		}
+	}
+	return status, version
+}
+
+// aggregateDeploymentHealth summarises a set of Deployments into a single readiness verdict,
+// the way `jx get env --watch` colours each row
+func aggregateDeploymentHealth(deps []appsv1beta1.Deployment) string {
+	if len(deps) == 0 {
+		return "-"
+	}
+	progressing := false
+	for _, d := range deps {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		if d.Status.AvailableReplicas < desired {
+			if d.Status.UpdatedReplicas < desired || d.Status.ReadyReplicas < desired {
+				progressing = true
+			} else {
+				return "Degraded"
+			}
+		}
+	}
+	if progressing {
+		return "Progressing"
+	}
+	return "Ready"
+}
+
+// colorDeploymentHealth colours an aggregateDeploymentHealth verdict for terminal output
+func colorDeploymentHealth(health string) string {
+	switch health {
+	case "Ready":
+		return util.ColorStatus(health)
+	case "Progressing":
+		return util.ColorWarning(health)
+	case "Degraded":
+		return util.ColorError(health)
+	default:
+		return health
+	}
+}
+
 func kindString(spec *v1.EnvironmentSpec) string {
 	answer := string(spec.Kind)
 	if answer == "" {
@@ -183,3 +286,132 @@ func (o *GetEnvOptions) matchesFilter(env *v1.Environment) bool {
 	}
 	return env.Spec.PromotionStrategy == v1.PromotionStrategyType(o.PromotionStrategy)
 }
+
+// watchEnvironments implements `jx get env --watch`. It watches the Environments in ns and, for
+// each one with a namespace, starts a parallel watch on its Deployments, redrawing the table
+// whenever either watch reports a change - similar to `kubectl get -w` but aggregated across
+// both resources. It runs until the process is interrupted.
+func (o *GetEnvOptions) watchEnvironments(jxClient versioned.Interface, kubeClient kubernetes.Interface, ns string) error {
+	envWatch, err := jxClient.JenkinsV1().Environments(ns).Watch(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	defer envWatch.Stop()
+
+	var mu sync.Mutex
+	states := map[string]*envDeploymentState{}
+	stopDeploymentWatches := map[string]chan struct{}{}
+	dirty := make(chan struct{}, 1)
+
+	markDirty := func() {
+		select {
+		case dirty <- struct{}{}:
+		default:
+		}
+	}
+
+	watchDeployments := func(envName string, depNs string, stop chan struct{}) {
+		depWatch, err := kubeClient.AppsV1beta1().Deployments(depNs).Watch(metav1.ListOptions{})
+		if err != nil {
+			o.warnf("Failed to watch deployments in namespace %s: %s\n", depNs, err)
+			return
+		}
+		defer depWatch.Stop()
+		for {
+			select {
+			case event, ok := <-depWatch.ResultChan():
+				if !ok {
+					return
+				}
+				dep, ok := event.Object.(*appsv1beta1.Deployment)
+				if !ok {
+					continue
+				}
+				mu.Lock()
+				if state := states[envName]; state != nil {
+					if event.Type == watch.Deleted {
+						delete(state.deployments, dep.Name)
+					} else {
+						state.deployments[dep.Name] = *dep
+					}
+				}
+				mu.Unlock()
+				markDirty()
+			case <-stop:
+				return
+			}
+		}
+	}
+
+	go func() {
+		for event := range envWatch.ResultChan() {
+			env, ok := event.Object.(*v1.Environment)
+			if !ok {
+				continue
+			}
+			if event.Type == watch.Deleted {
+				mu.Lock()
+				if stop, found := stopDeploymentWatches[env.Name]; found {
+					close(stop)
+					delete(stopDeploymentWatches, env.Name)
+				}
+				delete(states, env.Name)
+				mu.Unlock()
+				markDirty()
+				continue
+			}
+
+			mu.Lock()
+			state, found := states[env.Name]
+			if !found {
+				state = &envDeploymentState{deployments: map[string]appsv1beta1.Deployment{}}
+				states[env.Name] = state
+			}
+			state.env = *env
+			mu.Unlock()
+
+			if !found && env.Spec.Namespace != "" {
+				stop := make(chan struct{})
+				mu.Lock()
+				stopDeploymentWatches[env.Name] = stop
+				mu.Unlock()
+				go watchDeployments(env.Name, env.Spec.Namespace, stop)
+			}
+			markDirty()
+		}
+	}()
+
+	render := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		names := make([]string, 0, len(states))
+		for name := range states {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Fprint(o.Out, "\033[H\033[2J")
+		table := o.CreateTable()
+		table.AddRow("NAME", "NAMESPACE", "PROMOTE", "APPS", "STATUS")
+		for _, name := range names {
+			state := states[name]
+			spec := &state.env.Spec
+			deps := make([]appsv1beta1.Deployment, 0, len(state.deployments))
+			for _, d := range state.deployments {
+				deps = append(deps, d)
+			}
+			status := "-"
+			if len(deps) > 0 {
+				status = colorDeploymentHealth(aggregateDeploymentHealth(deps))
+			}
+			table.AddRow(name, spec.Namespace, string(spec.PromotionStrategy), util.Int32ToA(int32(len(deps))), status)
+		}
+		table.Render()
+	}
+
+	render()
+	for range dirty {
+		render()
+	}
+	return nil
+}
@@ -8,18 +8,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/draft"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jenkins"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	pipelinetemplates "github.com/jenkins-x/jx/pkg/pipeline/templates"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 	gitcfg "gopkg.in/src-d/go-git.v4/config"
-	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -36,119 +38,6 @@ target
 work
 `
 
-	// TODO figure out how to pass extra dockerfiles from a draft pack
-	defaultDockerfile = `
-FROM openjdk:8-jdk-alpine
-ENV PORT 8080
-EXPOSE 8080
-COPY target/*.jar /opt/app.jar
-WORKDIR /opt
-CMD ["java", "-jar", "app.jar"]
-`
-
-	// TODO replace with the jx-pipelines-plugin version when its available
-	defaultJenkinsfile = `
-pipeline {
-    agent {
-      label "jenkins-maven"
-    }
-
-    environment {
-      ORG 		        = 'jenkinsx'
-      APP_NAME          = '%s'
-      GIT_CREDS         = credentials('jenkins-x-git')
-      CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')
-
-      GIT_USERNAME      = "$GIT_CREDS_USR"
-      GIT_API_TOKEN     = "$GIT_CREDS_PSW"
-      JOB_NAME          = "$JOB_NAME"
-      BUILD_NUMBER      = "$BUILD_NUMBER"
-    }
-
-    stages {
-      stage('CI Build and push snapshpt') {
-        when {
-          branch 'PR-*'
-        }
-        environment {
-          PREVIEW_VERSION = "0.0.0-SNAPSHOT-$BRANCH_NAME-$BUILD_NUMBER"
-          PREVIEW_NAMESPACE = "$APP_NAME-$BRANCH_NAME".toLowerCase()
-          HELM_RELEASE = "$PREVIEW_NAMESPACE".toLowerCase()
-        }
-        steps {
-          container('maven') {
-            sh "mvn versions:set -DnewVersion=$PREVIEW_VERSION"
-            sh "mvn install"
-            sh "docker build -f Dockerfile.release -t $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:$PREVIEW_VERSION ."
-            sh "docker push $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:$PREVIEW_VERSION"
-          }
-
-		  // comment out until draft pack includes preview environment charts
-          //dir ('./charts/preview') {
-          //  container('maven') {
-          //    sh "make preview"
-          //  }
-          //}
-        }
-      }
-
-      stage('Build Release') {
-        when {
-          branch 'master'
-        }
-        steps {
-          container('maven') {
-            // ensure we're not on a detached head
-            sh "git checkout master"
-
-            // until we switch to the new kubernetes / jenkins credential implementation use git credentials store
-            sh "git config --global credential.helper store"
-
-            // so we can retrieve the version in later steps
-            sh "echo \$(jx-release-version) > VERSION"
-            sh "mvn versions:set -DnewVersion=\$(cat VERSION)"
-          }
-
-          dir ('./charts/%s') {
-            container('maven') {
-              sh "make tag"
-            }
-          }
-
-          container('maven') {
-            sh 'mvn clean deploy'
-            sh "docker build -f Dockerfile.release -t $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:\$(cat VERSION) ."
-            sh "docker push $JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT/$ORG/$APP_NAME:\$(cat VERSION)"
-            sh 'jx step changelog --version \$(cat VERSION)'
-          }
-        }
-      }
-
-      stage('Promote to Environments') {
-        environment {
-          GIT_USERNAME = "$GIT_CREDS_USR"
-          GIT_API_TOKEN = "$GIT_CREDS_PSW"
-        }
-        when {
-          branch 'master'
-        }
-        steps {
-          dir ('./charts/%s') {
-            container('maven') {
-
-              // release the helm chart
-              sh 'make release'
-
-              // promote through all 'Auto' promotion Environments
-              sh 'jx promote -b --all-auto --timeout 1h --version \$(cat ../../VERSION)'
-            }
-          }
-        }
-      }
-
-    }
-  }
-`
 )
 
 type ImportOptions struct {
@@ -169,11 +58,19 @@ type ImportOptions struct {
 	SelectFilter            string
 	Jenkinsfile             string
 	BranchPattern           string
+	TemplatePack            string
+	Engine                  string
+	WebhookEngine           string
+	FromFile                string
+	MaxConcurrentImports    int
+	JenkinsHealthTimeout    time.Duration
+	JenkinsRetryTimeout     time.Duration
 	GitRepositoryOptions    gits.GitRepositoryOptions
 	ImportGitCommitMessage  string
 
 	DisableDotGitSearch bool
 	Jenkins             *gojenkins.Jenkins
+	JenkinsSelector     JenkinsSelector
 	GitConfDir          string
 	GitProvider         gits.GitProvider
 }
@@ -254,6 +151,14 @@ func (options *ImportOptions) addImportFlags(cmd *cobra.Command, createProject b
 	cmd.Flags().BoolVarP(&options.DisableJenkinsfileCheck, "no-jenkinsfile", "", false, "Disable defaulting a Jenkinsfile if its missing")
 	cmd.Flags().StringVarP(&options.ImportGitCommitMessage, "import-commit-message", "", "", "The git commit message for the import")
 	cmd.Flags().StringVarP(&options.BranchPattern, "branches", "", "", "The branch pattern for branches to trigger CI / CD pipelines on. Defaults to '"+jenkins.DefaultBranchPattern+"'")
+	cmd.Flags().StringVarP(&options.TemplatePack, "template-pack", "", "", "The name of the template pack to use to default the Jenkinsfile, Dockerfile.release and chart skeleton. Defaults to auto-detecting the project language")
+	cmd.Flags().StringVarP(&options.Engine, "engine", "", "", "The pipeline engine to import into. Possible values: "+importEngineJenkins+", "+importEngineProw+". Defaults to auto-detecting from the dev namespace")
+	cmd.Flags().StringVarP(&options.WebhookEngine, "webhook-engine", "", "", "The kind of webhook to register for the git provider. Possible values: "+webhookEngineJenkins+", "+webhookEngineProw+". Defaults to "+webhookEngineJenkins)
+	cmd.Flags().StringVarP(&options.FromFile, "from-file", "", "", "Bulk imports every repo listed in this YAML manifest of {gitURL, branchPattern, jenkinsfile, credentialsRef} entries, non-interactively")
+	cmd.Flags().IntVarP(&options.MaxConcurrentImports, "max-concurrent-imports", "", 4, "Maximum number of repos from --from-file to import concurrently")
+	cmd.Flags().DurationVarP(&options.JenkinsHealthTimeout, "jenkins-health-timeout", "", 5*time.Minute, "How long to wait for a slow-starting Jenkins master to become healthy before importing")
+	cmd.Flags().DurationVarP(&options.JenkinsRetryTimeout, "jenkins-retry-timeout", "", time.Minute, "How long to keep retrying a Jenkins API call that fails with a transient error")
+	options.JenkinsSelector.addFlags(cmd)
 
 	options.addCommonFlags(cmd)
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
@@ -263,20 +168,40 @@ func (o *ImportOptions) Run() error {
 	f := o.Factory
 	f.SetBatch(o.BatchMode)
 
-	jenkins, err := f.CreateJenkinsClient()
+	client, ns, err := o.Factory.CreateClient()
 	if err != nil {
 		return err
 	}
+	o.currentNamespace = ns
+	o.kubeClient = client
 
-	o.Jenkins = jenkins
+	if o.TemplatePack == "" {
+		if ctx, ok := activeJXContext(); ok && ctx.DefaultTemplate != "" {
+			o.TemplatePack = ctx.DefaultTemplate
+		}
+	}
 
-	client, ns, err := o.Factory.CreateClient()
+	engine, err := o.resolveEngine()
 	if err != nil {
 		return err
 	}
-	o.currentNamespace = ns
-	o.kubeClient = client
+	if engine == importEngineJenkins {
+		jenkinsClient, err := o.JenkinsClientForSelector(&o.JenkinsSelector)
+		if err != nil {
+			return err
+		}
+		o.Jenkins = jenkinsClient
 
+		o.Printf("Waiting for Jenkins at %s to be ready...\n", util.ColorInfo(jenkinsClient.BaseURL()))
+		err = jenkins.CheckHealth(util.UrlJoin(jenkinsClient.BaseURL(), "login"), o.JenkinsHealthTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.FromFile != "" {
+		return o.ImportFromFile()
+	}
 	if o.GitHub {
 		return o.ImportProjectsFromGitHub()
 	}
@@ -352,17 +277,16 @@ func (o *ImportOptions) Run() error {
 		}
 	}
 
-	err = o.checkChartmuseumCredentialExists()
-	if err != nil {
-		return err
-	}
-
 	if o.DryRun {
 		log.Infof("dry-run so skipping import to Jenkins X")
 		return nil
 	}
 
-	return o.DoImport()
+	importer, err := importerForEngine(engine)
+	if err != nil {
+		return err
+	}
+	return importer.Import(o)
 }
 
 func (o *ImportOptions) ImportProjectsFromGitHub() error {
@@ -403,6 +327,8 @@ func (o *ImportOptions) ImportProjectsFromGitHub() error {
 			Organisation:            org,
 			Repository:              r.Name,
 			Jenkins:                 o.Jenkins,
+			JenkinsSelector:         o.JenkinsSelector,
+			WebhookEngine:           o.WebhookEngine,
 			GitProvider:             provider,
 			DisableJenkinsfileCheck: o.DisableJenkinsfileCheck,
 			DisableDraft:            o.DisableDraft,
@@ -419,16 +345,13 @@ func (o *ImportOptions) ImportProjectsFromGitHub() error {
 func (o *ImportOptions) DraftCreate() error {
 	args := []string{"create"}
 
-	// TODO this is a workaround of this draft issue:
-	// https://github.com/Azure/draft/issues/476
 	dir := o.Dir
-	pomName := filepath.Join(dir, "pom.xml")
-	exists, err := util.FileExists(pomName)
+	pack, err := draft.DoPackDetection(o.jxHome(), o.Out, dir)
 	if err != nil {
 		return err
 	}
-	if exists {
-		args = []string{"create", "--pack=github.com/jenkins-x/draft-repo/packs/java"}
+	if pack != "" {
+		args = []string{"create", "--pack=github.com/jenkins-x/draft-repo/packs/" + pack}
 	}
 	e := exec.Command("draft", args...)
 	e.Dir = dir
@@ -443,7 +366,7 @@ func (o *ImportOptions) DraftCreate() error {
 	// chart expects folder name to be the same as app name
 	oldChartsDir := filepath.Join(dir, "charts", "java")
 	newChartsDir := filepath.Join(dir, "charts", o.AppName)
-	exists, err = util.FileExists(oldChartsDir)
+	exists, err := util.FileExists(oldChartsDir)
 	if err != nil {
 		return err
 	}
@@ -480,10 +403,23 @@ func (o *ImportOptions) DraftCreate() error {
 	return nil
 }
 
+// DefaultJenkinsfile renders the Jenkinsfile from the project's template pack, unless one
+// already exists
 func (o *ImportOptions) DefaultJenkinsfile() error {
+	return o.renderTemplatePackFile("Jenkinsfile", "Added default Jenkinsfile pipeline")
+}
 
+// DefaultDockerfile renders the Dockerfile.release from the project's template pack, unless
+// one already exists
+func (o *ImportOptions) DefaultDockerfile() error {
+	return o.renderTemplatePackFile("Dockerfile.release", "Added Release Dockerfile pipeline")
+}
+
+// renderTemplatePackFile writes the named file from the resolved template pack into o.Dir,
+// leaving any existing file untouched, then commits it if it changed anything
+func (o *ImportOptions) renderTemplatePackFile(file string, commitMessage string) error {
 	dir := o.Dir
-	name := filepath.Join(dir, "Jenkinsfile")
+	name := filepath.Join(dir, file)
 	exists, err := util.FileExists(name)
 	if err != nil {
 		return err
@@ -492,47 +428,55 @@ func (o *ImportOptions) DefaultJenkinsfile() error {
 		return nil
 	}
 
-	data := []byte(fmt.Sprintf(defaultJenkinsfile, o.AppName, o.AppName, o.AppName))
-	err = ioutil.WriteFile(name, data, DefaultWritePermissions)
-	if err != nil {
-		return fmt.Errorf("Failed to write %s due to %s", name, err)
+	packName := o.TemplatePack
+	if packName == "" {
+		detected, err := draft.DoPackDetection(o.jxHome(), nil, dir)
+		if err != nil {
+			return err
+		}
+		if _, err := pipelinetemplates.LoadPack(o.jxHome(), detected); err == nil {
+			packName = detected
+		}
 	}
-	err = gits.GitAdd(dir, "Jenkinsfile")
+	pack, err := pipelinetemplates.LoadPack(o.jxHome(), packName)
 	if err != nil {
 		return err
 	}
-	err = gits.GitCommitIfChanges(dir, "Added default Jenkinsfile pipeline")
+	data, err := pack.Render(file, o.templateVariables())
 	if err != nil {
 		return err
 	}
-	return nil
-}
 
-func (o *ImportOptions) DefaultDockerfile() error {
-
-	dir := o.Dir
-	name := filepath.Join(dir, "Dockerfile.release")
-	exists, err := util.FileExists(name)
-	if err != nil {
-		return err
-	}
-	if exists {
-		return nil
-	}
-	data := []byte(defaultDockerfile)
 	err = ioutil.WriteFile(name, data, DefaultWritePermissions)
 	if err != nil {
 		return fmt.Errorf("Failed to write %s due to %s", name, err)
 	}
-	err = gits.GitAdd(dir, "Dockerfile.release")
+	err = gits.GitAdd(dir, file)
 	if err != nil {
 		return err
 	}
-	err = gits.GitCommitIfChanges(dir, "Added Release Dockerfile pipeline")
-	if err != nil {
-		return err
+	return gits.GitCommitIfChanges(dir, commitMessage)
+}
+
+// templateVariables returns the variable bag used to render the current project's template pack
+func (o *ImportOptions) templateVariables() pipelinetemplates.Variables {
+	branchPattern := o.BranchPattern
+	if branchPattern == "" {
+		branchPattern = jenkins.DefaultBranchPattern
 	}
-	return nil
+	return pipelinetemplates.Variables{
+		AppName:                 o.AppName,
+		Organisation:            o.Organisation,
+		DockerRegistry:          "$JENKINS_X_DOCKER_REGISTRY_SERVICE_HOST:$JENKINS_X_DOCKER_REGISTRY_SERVICE_PORT",
+		ChartsRepo:              filepath.Join(o.Dir, "charts", o.AppName),
+		PreviewNamespacePattern: fmt.Sprintf("%s-$BRANCH_NAME", o.AppName),
+		BranchPatterns:          branchPattern,
+	}
+}
+
+// jxHome returns the $JX_HOME directory used to look up user template pack overrides
+func (o *ImportOptions) jxHome() string {
+	return jxHomeDir()
 }
 
 func (o *ImportOptions) CreateNewRemoteRepository() error {
@@ -733,35 +677,6 @@ func (o *ImportOptions) DiscoverRemoteGitURL() error {
 	return nil
 }
 
-func (o *ImportOptions) DoImport() error {
-	if o.Jenkins == nil {
-		jenkins, err := o.Factory.CreateJenkinsClient()
-		if err != nil {
-			return err
-		}
-		o.Jenkins = jenkins
-	}
-	gitURL := o.RepoURL
-	gitProvider := o.GitProvider
-	if gitProvider == nil {
-		p, err := o.gitProviderForURL(gitURL, "user name to register webhook")
-		if err != nil {
-			return err
-		}
-		gitProvider = p
-	}
-
-	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
-	if err != nil {
-		return err
-	}
-	jenkinsfile := o.Jenkinsfile
-	if jenkinsfile == "" {
-		jenkinsfile = jenkins.DefaultJenkinsfile
-	}
-	return jenkins.ImportProject(o.Out, o.Jenkins, gitURL, o.Dir, jenkinsfile, o.BranchPattern, o.Credentials, false, gitProvider, authConfigSvc)
-}
-
 func (o *ImportOptions) addAppNameToGeneratedFile(filename, field, value string) error {
 	dir := filepath.Join(o.Dir, "charts", o.AppName)
 	file := filepath.Join(dir, filename)
@@ -793,25 +708,3 @@ func (o *ImportOptions) addAppNameToGeneratedFile(filename, field, value string)
 	return nil
 }
 
-func (o *ImportOptions) checkChartmuseumCredentialExists() error {
-
-	name := jenkins.DefaultJenkinsCredentialsPrefix + jenkins.Chartmuseum
-	_, err := o.Jenkins.GetCredential(name)
-
-	if err != nil {
-		secret, err := o.kubeClient.CoreV1().Secrets(o.currentNamespace).Get(name, meta_v1.GetOptions{})
-		if err != nil {
-			fmt.Errorf("error getting %s secret %v", name, err)
-		}
-
-		data := secret.Data
-		username := string(data["BASIC_AUTH_USER"])
-		password := string(data["BASIC_AUTH_PASS"])
-
-		err = o.Jenkins.CreateCredential(name, username, password)
-		if err != nil {
-			return fmt.Errorf("error creating jenkins credential %s %v", name, err)
-		}
-	}
-	return nil
-}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	importEngineJenkins = "jenkins"
+	importEngineProw    = "prow"
+
+	prowPluginsConfigMapName = "plugins"
+
+	// webhookEngineJenkins registers a classic per-job Jenkins webhook path
+	webhookEngineJenkins = "jenkins"
+	// webhookEngineProw registers the shared Prow/Lighthouse hook service URL instead
+	webhookEngineProw = "prow"
+)
+
+// Importer performs the final step of 'jx import': registering the already prepared project
+// with whichever pipeline engine is running in the current team's dev environment
+type Importer interface {
+	Import(o *ImportOptions) error
+}
+
+// importerForEngine returns the Importer implementation for the given engine name
+func importerForEngine(engine string) (Importer, error) {
+	switch engine {
+	case importEngineJenkins, "":
+		return &JenkinsImporter{}, nil
+	case importEngineProw:
+		return &ProwImporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown import engine %s, must be one of: %s, %s", engine, importEngineJenkins, importEngineProw)
+	}
+}
+
+// resolveEngine returns the explicitly requested --engine, or otherwise auto-detects one by
+// checking whether the dev namespace is running Prow (identified by its "plugins" ConfigMap)
+// rather than a classic Jenkins master
+func (o *ImportOptions) resolveEngine() (string, error) {
+	if o.Engine != "" {
+		return o.Engine, nil
+	}
+
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = client.CoreV1().ConfigMaps(ns).Get(prowPluginsConfigMapName, metav1.GetOptions{})
+	if err == nil {
+		return importEngineProw, nil
+	}
+	return importEngineJenkins, nil
+}
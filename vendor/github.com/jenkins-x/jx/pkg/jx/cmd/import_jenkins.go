@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsImporter registers the imported project as a Jenkins MultiBranchProject job
+type JenkinsImporter struct {
+}
+
+// Import implements Importer for classic Jenkins master pipelines
+func (i *JenkinsImporter) Import(o *ImportOptions) error {
+	err := o.checkChartmuseumCredentialExists()
+	if err != nil {
+		return err
+	}
+
+	if o.Jenkins == nil {
+		jenk, err := o.JenkinsClientForSelector(&o.JenkinsSelector)
+		if err != nil {
+			return err
+		}
+		o.Jenkins = jenk
+	}
+	gitURL := o.RepoURL
+	gitProvider := o.GitProvider
+	if gitProvider == nil {
+		p, err := o.gitProviderForURL(gitURL, "user name to register webhook")
+		if err != nil {
+			return err
+		}
+		gitProvider = p
+	}
+
+	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	if err != nil {
+		return err
+	}
+	jenkinsfile := o.Jenkinsfile
+	if jenkinsfile == "" {
+		jenkinsfile = jenkins.DefaultJenkinsfile
+	}
+	return o.ImportProject(gitURL, o.Dir, jenkinsfile, o.BranchPattern, o.Credentials, false, gitProvider, authConfigSvc, false, o.BatchMode, &o.JenkinsSelector, o.JenkinsHealthTimeout, o.JenkinsRetryTimeout, o.WebhookEngine)
+}
+
+// checkChartmuseumCredentialExists ensures the Jenkins master has a credential for the
+// ChartMuseum used to push Helm charts, creating it from the cluster Secret if missing
+func (o *ImportOptions) checkChartmuseumCredentialExists() error {
+	if o.Jenkins == nil {
+		jenk, err := o.JenkinsClientForSelector(&o.JenkinsSelector)
+		if err != nil {
+			return err
+		}
+		o.Jenkins = jenk
+	}
+
+	name := jenkins.DefaultJenkinsCredentialsPrefix + jenkins.Chartmuseum
+	_, err := o.Jenkins.GetCredential(name)
+
+	if err != nil {
+		secret, err := o.kubeClient.CoreV1().Secrets(o.currentNamespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting %s secret %v", name, err)
+		}
+
+		data := secret.Data
+		username := string(data["BASIC_AUTH_USER"])
+		password := string(data["BASIC_AUTH_PASS"])
+
+		err = o.Jenkins.CreateCredential(name, username, password)
+		if err != nil {
+			return fmt.Errorf("error creating jenkins credential %s %v", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const prowConfigMapName = "config"
+
+// ProwImporter registers the imported project with a Prow/Tekton (or Lighthouse) pipeline
+// engine instead of a classic Jenkins master
+type ProwImporter struct {
+}
+
+// Import implements Importer for Prow/Tekton based clusters
+func (i *ProwImporter) Import(o *ImportOptions) error {
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+
+	gitInfo, err := gits.ParseGitURL(o.RepoURL)
+	if err != nil {
+		return fmt.Errorf("Failed to parse git URL %s due to: %s", o.RepoURL, err)
+	}
+
+	err = addRepoToProwConfig(client, ns, gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+
+	err = addRepoToProwPlugins(client, ns, gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return err
+	}
+
+	gitProvider := o.GitProvider
+	if gitProvider == nil {
+		gitProvider, err = o.gitProviderForURL(o.RepoURL, "user name to register webhook")
+		if err != nil {
+			return err
+		}
+	}
+	err = o.registerPipelineWebHook(nil, gitProvider, gitInfo, o.RepoURL, webhookEngineProw, o.JenkinsRetryTimeout)
+	if err != nil {
+		return err
+	}
+
+	o.Printf("Registered %s with Prow in namespace %s\n", util.ColorInfo(gitInfo.Name), util.ColorInfo(ns))
+
+	err = o.renderTemplatePackFile("jenkins-x.yml", "Added default jenkins-x.yml pipeline")
+	if err != nil {
+		// not every template pack ships a jenkins-x.yml yet so don't fail the import
+		o.Printf(util.ColorWarning("WARNING: %s, skipping pipeline file defaulting\n"), err)
+		return nil
+	}
+	return nil
+}
+
+// addRepoToProwConfig adds the owner/repo to the Prow "config" ConfigMap in ns if it isn't
+// already present
+func addRepoToProwConfig(client kubernetes.Interface, ns string, owner string, repo string) error {
+	return addRepoToProwConfigMap(client, ns, prowConfigMapName, owner, repo)
+}
+
+// addRepoToProwPlugins adds the owner/repo to the Prow "plugins" ConfigMap in ns if it isn't
+// already present
+func addRepoToProwPlugins(client kubernetes.Interface, ns string, owner string, repo string) error {
+	return addRepoToProwConfigMap(client, ns, prowPluginsConfigMapName, owner, repo)
+}
+
+func addRepoToProwConfigMap(client kubernetes.Interface, ns string, name string, owner string, repo string) error {
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(name, metav1.GetOptions{})
+	create := false
+	if err != nil {
+		create = true
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+			},
+		}
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+
+	key := owner + "/" + repo
+	if _, exists := cm.Data[key]; exists {
+		return nil
+	}
+	cm.Data[key] = "true"
+
+	if create {
+		_, err = client.CoreV1().ConfigMaps(ns).Create(cm)
+	} else {
+		_, err = client.CoreV1().ConfigMaps(ns).Update(cm)
+	}
+	return err
+}
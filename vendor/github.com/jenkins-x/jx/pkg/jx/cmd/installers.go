@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+)
+
+// versionStreamDir is the local checkout of the version stream populated by 'jx upgrade versions'
+func versionStreamDir() string {
+	return filepath.Join(jxHomeDir(), "versionStream")
+}
+
+// resolvePinnedVersion looks up name in the local version stream checkout, returning nil (with no
+// error) if 'jx upgrade versions' has never been run or the stream has no entry for name yet, so
+// callers fall back to resolving the latest upstream release instead
+func resolvePinnedVersion(name string) (*versionstream.PackageVersion, error) {
+	if _, err := os.Stat(versionStreamDir()); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return versionstream.ResolvePackage(versionStreamDir(), name)
+}
+
+// Installer installs a single external CLI dependency (kubectl, helm, kops, ...) that jx shells
+// out to. Implementations are registered in installerRegistry at init time so
+// doInstallMissingDependencies no longer has to know the download/verification details of every
+// tool it can install
+type Installer interface {
+	Install(o *CommonOptions) error
+}
+
+var installerRegistry = map[string]Installer{}
+
+// registerInstaller adds an Installer to the registry under name, used by doInstallMissingDependencies
+func registerInstaller(name string, installer Installer) {
+	installerRegistry[name] = installer
+}
+
+// versionFromGitHub returns a GitHubBinaryInstaller.VersionFunc that resolves the latest release
+// tag of org/repo
+func versionFromGitHub(org string, repo string) func(o *CommonOptions) (string, error) {
+	return func(o *CommonOptions) (string, error) {
+		v, err := util.GetLatestVersionFromGitHub(org, repo)
+		if err != nil {
+			return "", err
+		}
+		return v.String(), nil
+	}
+}
+
+// GitHubBinaryInstaller installs a single binary published as a release asset, verifying its
+// SHA256 checksum (published alongside the asset, typically as "<asset>.sha256") before it's
+// renamed into place on the $PATH, and optionally verifying a cosign/sigstore signature when the
+// project publishes one
+type GitHubBinaryInstaller struct {
+	// Binary is the name the installed executable is given on the $PATH
+	Binary string
+	// BrewFormula, if set, is installed via brew instead on darwin (unless --no-brew is passed)
+	BrewFormula string
+	// BrewCask marks BrewFormula as a "brew cask" package rather than a regular formula
+	BrewCask bool
+	// VersionFunc resolves the version to install, e.g. via versionFromGitHub
+	VersionFunc func(o *CommonOptions) (string, error)
+	// URL renders the download URL for the resolved version and the local OS/arch
+	URL func(version, goos, goarch string) string
+	// Archive is "tar.gz" or "" for a bare binary download
+	Archive string
+	// ChecksumSuffix is appended to the asset URL to find its checksum file, e.g. ".sha256".
+	// The install is refused if the checksum file can't be downloaded or if it doesn't match
+	ChecksumSuffix string
+	// CosignPublicKeyURL, if set, is downloaded and used to verify a "<asset>.sig" cosign/sigstore
+	// signature published alongside the asset
+	CosignPublicKeyURL string
+}
+
+// Install downloads, verifies and installs the binary described by g
+func (g *GitHubBinaryInstaller) Install(o *CommonOptions) error {
+	if g.BrewFormula != "" && runtime.GOOS == "darwin" && !o.NoBrew {
+		if g.BrewCask {
+			return o.runCommand("brew", "cask", "install", g.BrewFormula)
+		}
+		return o.runCommand("brew", "install", g.BrewFormula)
+	}
+
+	binDir, err := util.BinaryLocation()
+	if err != nil {
+		return err
+	}
+	fileName, flag, err := o.shouldInstallBinary(binDir, g.Binary)
+	if err != nil || !flag {
+		return err
+	}
+
+	pinned, err := resolvePinnedVersion(g.Binary)
+	if err != nil {
+		return err
+	}
+	var version, clientURL, expectedChecksum string
+	if pinned != nil && pinned.Version != "" {
+		version = pinned.Version
+		expectedChecksum = pinned.SHA256
+		clientURL = pinned.URL
+	} else {
+		version, err = g.VersionFunc(o)
+		if err != nil {
+			return fmt.Errorf("Unable to get latest version for %s: %s", g.Binary, err)
+		}
+	}
+	if clientURL == "" {
+		clientURL = g.URL(version, runtime.GOOS, runtime.GOARCH)
+	}
+	fullPath := filepath.Join(binDir, fileName)
+
+	downloadPath := fullPath + ".tmp"
+	if g.Archive != "" {
+		downloadPath = filepath.Join(binDir, g.Binary+"."+g.Archive)
+	}
+	o.Emit(InstallEvent{Phase: "download", Tool: g.Binary, URL: clientURL, Version: version})
+	err = o.downloadFileWithChecksum(clientURL, downloadPath, expectedChecksum)
+	if err != nil {
+		o.Emit(InstallEvent{Phase: "download", Tool: g.Binary, Error: err.Error()})
+		return err
+	}
+	err = o.verifyChecksum(downloadPath, clientURL, g.ChecksumSuffix, expectedChecksum)
+	if err != nil {
+		os.Remove(downloadPath)
+		o.Emit(InstallEvent{Phase: "verify", Tool: g.Binary, Error: err.Error()})
+		return err
+	}
+	o.Emit(InstallEvent{Phase: "verify", Tool: g.Binary, SHA256: expectedChecksum})
+	err = o.verifyCosignSignature(downloadPath, clientURL, g.CosignPublicKeyURL)
+	if err != nil {
+		os.Remove(downloadPath)
+		o.Emit(InstallEvent{Phase: "verify", Tool: g.Binary, Error: err.Error()})
+		return err
+	}
+
+	if g.Archive == "tar.gz" {
+		err = util.UnTargz(downloadPath, binDir, []string{g.Binary, fileName})
+		if err != nil {
+			return err
+		}
+		err = os.Remove(downloadPath)
+	} else {
+		err = util.RenameFile(downloadPath, fullPath)
+	}
+	if err != nil {
+		o.Emit(InstallEvent{Phase: "installed", Tool: g.Binary, Error: err.Error()})
+		return err
+	}
+	err = os.Chmod(fullPath, 0755)
+	if err != nil {
+		o.Emit(InstallEvent{Phase: "installed", Tool: g.Binary, Error: err.Error()})
+		return err
+	}
+	o.Emit(InstallEvent{Phase: "installed", Tool: g.Binary, Version: version, Path: fullPath})
+	return nil
+}
+
+// verifyChecksum confirms the SHA256 of the file already downloaded at path matches expectedChecksum,
+// refusing to install on any mismatch. If expectedChecksum is empty (no pinned version stream entry
+// for this package), it's instead downloaded from assetURL+checksumSuffix; if checksumSuffix is also
+// empty, verification is skipped entirely since no checksum was ever asserted for this installer.
+// But once checksumSuffix is configured, the installer's author is asserting a checksum file exists,
+// so failing to fetch or parse it is a hard error rather than a silent "installing unverified" -
+// otherwise an attacker (or a flaky CDN) can defeat verification just by making the checksum file
+// unreachable.
+func (o *CommonOptions) verifyChecksum(path string, assetURL string, checksumSuffix string, expectedChecksum string) error {
+	expected := expectedChecksum
+	if expected == "" {
+		if checksumSuffix == "" {
+			return nil
+		}
+		checksumURL := assetURL + checksumSuffix
+		response, err := http.Get(checksumURL)
+		if err != nil {
+			return fmt.Errorf("failed to download checksum file %s: %s - refusing to install a possibly tampered binary", checksumURL, err)
+		}
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("checksum file %s returned status %d - refusing to install a possibly tampered binary", checksumURL, response.StatusCode)
+		}
+		defer response.Body.Close()
+		data, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return fmt.Errorf("unable to read checksum file %s: %s - refusing to install a possibly tampered binary", checksumURL, err)
+		}
+		fields := strings.Fields(strings.TrimSpace(string(data)))
+		if len(fields) == 0 {
+			return fmt.Errorf("checksum file %s is empty - refusing to install a possibly tampered binary", checksumURL)
+		}
+		expected = fields[0]
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, f)
+	if err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(hasher.Sum(nil))
+
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s but downloaded file hashes to %s - refusing to install a possibly tampered binary", assetURL, expected, actual)
+	}
+	return nil
+}
+
+// verifyCosignSignature downloads the cosign/sigstore signature published at assetURL+".sig" and
+// verifies it against publicKeyURL using the cosign CLI. If publicKeyURL is empty, verification is
+// skipped since no signature was ever asserted for this installer. But once publicKeyURL is
+// configured, failing to download the signature is a hard error rather than a silent skip -
+// otherwise making assetURL+".sig" unreachable would be enough to defeat verification entirely.
+func (o *CommonOptions) verifyCosignSignature(path string, assetURL string, publicKeyURL string) error {
+	if publicKeyURL == "" {
+		return nil
+	}
+	sigFile := path + ".sig"
+	err := o.downloadFile(assetURL+".sig", sigFile)
+	if err != nil {
+		return fmt.Errorf("failed to download cosign signature %s.sig: %s - refusing to install a possibly tampered binary", assetURL, err)
+	}
+	defer os.Remove(sigFile)
+
+	keyFile := path + ".cosign.pub"
+	err = o.downloadFile(publicKeyURL, keyFile)
+	if err != nil {
+		return fmt.Errorf("Failed to download cosign public key %s: %s", publicKeyURL, err)
+	}
+	defer os.Remove(keyFile)
+
+	return o.runCommand("cosign", "verify-blob", "--key", keyFile, "--signature", sigFile, path)
+}
+
+func init() {
+	registerInstaller("kubectl", &GitHubBinaryInstaller{
+		Binary:      "kubectl",
+		BrewFormula: "kubectl",
+		VersionFunc: func(o *CommonOptions) (string, error) {
+			v, err := o.getLatestVersionFromKubernetesReleaseUrl()
+			if err != nil {
+				return "", err
+			}
+			return v.String(), nil
+		},
+		URL: func(version, goos, goarch string) string {
+			return fmt.Sprintf("https://storage.googleapis.com/kubernetes-release/release/v%s/bin/%s/%s/kubectl", version, goos, goarch)
+		},
+		ChecksumSuffix: ".sha256",
+	})
+
+	registerInstaller("helm", &GitHubBinaryInstaller{
+		Binary:      "helm",
+		BrewFormula: "kubernetes-helm",
+		VersionFunc: versionFromGitHub("kubernetes", "helm"),
+		URL: func(version, goos, goarch string) string {
+			return fmt.Sprintf("https://storage.googleapis.com/kubernetes-helm/helm-v%s-%s-%s.tar.gz", version, goos, goarch)
+		},
+		Archive:            "tar.gz",
+		ChecksumSuffix:     ".sha256",
+		CosignPublicKeyURL: "https://raw.githubusercontent.com/helm/helm/main/cosign.pub",
+	})
+
+	registerInstaller("kops", &GitHubBinaryInstaller{
+		Binary:      "kops",
+		BrewFormula: "kops",
+		VersionFunc: versionFromGitHub("kubernetes", "kops"),
+		URL: func(version, goos, goarch string) string {
+			return fmt.Sprintf("https://github.com/kubernetes/kops/releases/download/%s/kops-%s-%s", version, goos, goarch)
+		},
+		ChecksumSuffix:     ".sha256",
+		CosignPublicKeyURL: "https://raw.githubusercontent.com/kubernetes/kops/master/kops.pub",
+	})
+
+	registerInstaller("minikube", &GitHubBinaryInstaller{
+		Binary:      "minikube",
+		BrewFormula: "minikube",
+		BrewCask:    true,
+		VersionFunc: versionFromGitHub("kubernetes", "minikube"),
+		URL: func(version, goos, goarch string) string {
+			return fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/minikube-%s-%s", version, goos, goarch)
+		},
+		ChecksumSuffix:     ".sha256",
+		CosignPublicKeyURL: "https://storage.googleapis.com/minikube/release/cosign.pub",
+	})
+
+	registerInstaller("ksync", &GitHubBinaryInstaller{
+		Binary:      "ksync",
+		VersionFunc: versionFromGitHub("vapor-ware", "ksync"),
+		URL: func(version, goos, goarch string) string {
+			suffix := ""
+			if goos == "windows" {
+				suffix = ".exe"
+			}
+			return fmt.Sprintf("https://github.com/vapor-ware/ksync/releases/download/%s/ksync_%s_%s%s", version, goos, goarch, suffix)
+		},
+		ChecksumSuffix: ".sha256",
+	})
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanLong = templates.LongDesc(`
+		Scan container images for vulnerabilities using whichever CVE addon is installed
+`)
+)
+
+// ScanOptions contains the command line flags shared by all "jx scan" sub-commands
+type ScanOptions struct {
+	CommonOptions
+}
+
+// NewCmdScan creates the "scan" command object
+func NewCmdScan(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ScanOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "scan",
+		Short: "scan resources for vulnerabilities",
+		Long:  scanLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdScanImage(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *ScanOptions) Run() error {
+	return o.Cmd.Help()
+}
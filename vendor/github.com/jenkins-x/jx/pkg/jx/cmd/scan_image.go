@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cloudevent"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/cve"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// scanSeverityRank orders severities from least to most serious so --fail-on can compare the
+// worst vulnerability found against the requested threshold
+var scanSeverityRank = map[string]int{
+	"negligible": 0,
+	"low":        1,
+	"medium":     2,
+	"high":       3,
+	"critical":   4,
+}
+
+var (
+	scanImageLong = templates.LongDesc(`
+		Scans a container image for vulnerabilities using whichever CVE scanner addon is
+		installed, printing a table of the vulnerabilities found and the policy evaluation
+		result.
+
+		This uses the same addonAuth.yaml credentials that 'jx create addon anchore' (or
+		'jx create addon clair') writes, so a scanner addon must already be installed in the
+		team's dev namespace.
+`)
+
+	scanImageExample = templates.Examples(`
+		# Scan an image and print the vulnerability table
+		jx scan image gcr.io/myproject/myapp:1.0.0
+
+		# Scan an image and fail the command if any high or critical vulnerability is found
+		jx scan image gcr.io/myproject/myapp:1.0.0 --fail-on high
+	`)
+
+	scanImageSeverities = []string{"critical", "high", "medium", "low", "negligible"}
+)
+
+// ScanImageOptions the command line options for "jx scan image"
+type ScanImageOptions struct {
+	CommonOptions
+
+	Image      string
+	FailOn     string
+	EventsSink string
+}
+
+// NewCmdScanImage creates the "scan image" command object
+func NewCmdScanImage(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ScanImageOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "image [image reference]",
+		Short:   "Scans a container image for vulnerabilities using the installed CVE scanner addon",
+		Long:    scanImageLong,
+		Example: scanImageExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.FailOn, "fail-on", "", "", "Fail the command if a vulnerability at or above this severity is found. Possible values: "+strings.Join(scanImageSeverities, ", "))
+	cmd.Flags().StringVarP(&options.EventsSink, "events-sink", "", "", fmt.Sprintf("URL to publish a CloudEvents '%s' event to once the scan completes, so pipelines can gate on the verdict with 'jx step wait cve-event' instead of polling. Defaults to the %s environment variable", cloudevent.CVEScanCompletedType, cloudevent.EnvSinkURL))
+	return cmd
+}
+
+// Run implements this command
+func (o *ScanImageOptions) Run() error {
+	args := o.Args
+	if len(args) == 0 {
+		if o.Image == "" {
+			return util.MissingOption("image")
+		}
+	} else {
+		o.Image = args[0]
+	}
+
+	if o.FailOn != "" {
+		if _, ok := scanSeverityRank[o.FailOn]; !ok {
+			return util.InvalidOption("fail-on", o.FailOn, scanImageSeverities)
+		}
+	}
+
+	_, _, err := o.KubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot connect to kubernetes cluster: %v", err)
+	}
+
+	server, auth, err := o.CommonOptions.getAddonAuthByKind(kube.ValueKindCVE)
+	if err != nil {
+		return fmt.Errorf("error getting CVE scanner auth details, try running `jx create addon anchore`: %v", err)
+	}
+
+	scanner, err := cve.NewScanner(server.Name)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	o.Printf("Submitting %s to %s for analysis\n", util.ColorInfo(o.Image), util.ColorInfo(scanner.Kind()))
+	report, err := scanner.ScanImage(client, server.URL, auth.Username, auth.Password, o.Image)
+	if err != nil {
+		return fmt.Errorf("failed to scan image %s: %v", o.Image, err)
+	}
+
+	table := o.CreateTable()
+	table.AddRow("Image", util.ColorInfo("Severity"), "Vulnerability", "Package", "Fix")
+	worst := "negligible"
+	found := false
+	for _, v := range report.Vulnerabilities {
+		found = true
+		table.AddRow(o.Image, v.Severity, v.Vuln, v.Package, v.Fix)
+		if scanSeverityRank[strings.ToLower(v.Severity)] > scanSeverityRank[worst] {
+			worst = strings.ToLower(v.Severity)
+		}
+	}
+	table.Render()
+
+	o.Printf("Policy evaluation: %s\n", util.ColorInfo(report.PolicyStatus))
+
+	o.publishScanCompletedEvent(scanner.Kind(), report)
+
+	if o.FailOn != "" && found && scanSeverityRank[worst] >= scanSeverityRank[o.FailOn] {
+		return fmt.Errorf("found a %s severity vulnerability in %s which is at or above the --fail-on threshold of %s", worst, o.Image, o.FailOn)
+	}
+	return nil
+}
+
+// publishScanCompletedEvent publishes a CloudEvents notification of report to the configured
+// events sink, if any. Failing to publish doesn't fail the scan itself - it just means pipeline
+// stages relying on 'jx step wait cve-event' will time out rather than see a stale verdict
+func (o *ScanImageOptions) publishScanCompletedEvent(providerKind string, report *cve.Report) {
+	sinkURL := cloudevent.SinkURL(o.EventsSink)
+	if sinkURL == "" {
+		return
+	}
+
+	counts := map[string]int{}
+	for _, v := range report.Vulnerabilities {
+		counts[strings.ToLower(v.Severity)]++
+	}
+
+	event := cloudevent.NewScanCompletedEvent(providerKind, report.Digest, cloudevent.ScanCompletedData{
+		Image:               o.Image,
+		Digest:              report.Digest,
+		Provider:            providerKind,
+		PolicyStatus:        report.PolicyStatus,
+		VulnerabilityCounts: counts,
+	})
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	if err := cloudevent.Publish(client, sinkURL, event); err != nil {
+		log.Warnf("failed to publish %s event to %s: %v\n", cloudevent.CVEScanCompletedType, sinkURL, err)
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stepLong = templates.LongDesc(`
+		Pipeline steps are lower level commands typically used inside a Jenkinsfile or build pack
+		pipeline rather than being invoked directly by developers
+`)
+)
+
+// StepOptions contains the command line flags shared by all "jx step" sub-commands
+type StepOptions struct {
+	CommonOptions
+}
+
+// NewCmdStep creates the "step" command object
+func NewCmdStep(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "step",
+		Short: "pipeline steps",
+		Long:  stepLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdStepPR(f, out, errOut))
+	cmd.AddCommand(NewCmdStepVerify(f, out, errOut))
+	cmd.AddCommand(NewCmdStepWait(f, out, errOut))
+	cmd.AddCommand(NewCmdScanImage(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *StepOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stepPRLong = templates.LongDesc(`
+		Pipeline steps for working with Pull Requests
+
+`)
+)
+
+// StepPROptions contains the command line flags shared by all "jx step pr" sub-commands
+type StepPROptions struct {
+	StepOptions
+}
+
+// NewCmdStepPR creates the "step pr" command object
+func NewCmdStepPR(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepPROptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pr",
+		Short: "pipeline step pr",
+		Long:  stepPRLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdStepPRComment(f, out, errOut))
+	cmd.AddCommand(NewCmdStepPRReview(f, out, errOut))
+	cmd.AddCommand(NewCmdStepPRLabel(f, out, errOut))
+
+	return cmd
+}
+
+// addStepPRFlags registers the owner/repository/pull-request/pr-branch flags shared by every
+// "jx step pr" sub-command
+func addStepPRFlags(cmd *cobra.Command, owner, repository, pr, prBranch *string) {
+	cmd.Flags().StringVarP(owner, "owner", "o", "", "git organisation / owner. Defaults to the owner of the current git repository")
+	cmd.Flags().StringVarP(repository, "repository", "r", "", "git repository. Defaults to the name of the current git repository")
+	cmd.Flags().StringVarP(pr, "pull-request", "p", "", "git pull request number. Defaults to the open PR for --pr-branch")
+	cmd.Flags().StringVarP(prBranch, "pr-branch", "b", "", "the git branch to look up the open Pull Request for when --pull-request is not specified. Defaults to the current branch")
+}
+
+// resolveProviderAndPR resolves the owner, repository, git provider and Pull Request to act on,
+// defaulting owner/repository from the current git repository and the PR number from the open
+// Pull Request for --pr-branch (or the current branch) when --pull-request is not specified
+func (o *StepPROptions) resolveProviderAndPR(owner, repository, prNumber, prBranch string) (gits.GitProvider, *gits.GitPullRequest, error) {
+	gitInfo, err := gits.GetGitInfo("")
+	if err != nil {
+		return nil, nil, err
+	}
+	if owner == "" {
+		owner = gitInfo.Organisation
+	}
+	if repository == "" {
+		repository = gitInfo.Name
+	}
+	if owner == "" {
+		return nil, nil, util.MissingOption("owner")
+	}
+	if repository == "" {
+		return nil, nil, util.MissingOption("repository")
+	}
+
+	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	if err != nil {
+		return nil, nil, err
+	}
+	gitKind, err := o.GitServerKind(gitInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	provider, err := gitInfo.PickOrCreateProvider(authConfigSvc, "user name to submit PR action as", o.BatchMode, gitKind)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	number, err := o.resolvePRNumber(provider, owner, repository, prNumber, prBranch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pr := &gits.GitPullRequest{
+		Owner:  owner,
+		Repo:   repository,
+		Number: &number,
+	}
+	return provider, pr, nil
+}
+
+// resolvePRNumber returns the given prNumber if set, otherwise looks up the open Pull Request
+// for prBranch (or the current git branch if prBranch is blank)
+func (o *StepPROptions) resolvePRNumber(provider gits.GitProvider, owner string, repository string, prNumber string, prBranch string) (int, error) {
+	if prNumber != "" {
+		return strconv.Atoi(prNumber)
+	}
+
+	branch := prBranch
+	if branch == "" {
+		var err error
+		branch, err = currentGitBranch()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	pr, err := provider.GetOpenPullRequestForBranch(owner, repository, branch)
+	if err != nil {
+		return 0, err
+	}
+	if pr == nil || pr.Number == nil {
+		return 0, util.InvalidOption("pr-branch", branch, []string{})
+	}
+	return *pr.Number, nil
+}
+
+// currentGitBranch returns the name of the currently checked out git branch
+func currentGitBranch() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// bodyFromFlagOrFile returns text supplied directly or, when fromFile is set, loads it from disk
+func bodyFromFlagOrFile(text string, fromFile string) (string, error) {
+	if fromFile == "" {
+		return text, nil
+	}
+	data, err := ioutil.ReadFile(fromFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %s", fromFile, err)
+	}
+	return string(data), nil
+}
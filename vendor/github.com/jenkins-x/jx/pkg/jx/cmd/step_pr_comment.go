@@ -1,34 +1,32 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
 
-	"strconv"
-
-	"fmt"
-
-	"github.com/jenkins-x/jx/pkg/gits"
 	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
 )
 
-// GetOptions is the start of the data required to perform the operation.  As new fields are added, add them here instead of
-// referencing the cmd.Flags()
+// StepPRCommentOptions contains the command line flags for "jx step pr comment"
 type StepPRCommentOptions struct {
 	StepPROptions
 	Flags StepPRCommentFlags
 }
 
+// StepPRCommentFlags are the flags for "jx step pr comment"
 type StepPRCommentFlags struct {
 	Comment    string
+	FromFile   string
 	URL        string
 	Owner      string
 	Repository string
 	PR         string
+	PRBranch   string
 }
 
-// NewCmdStep Steps a command object for the "step" command
+// NewCmdStepPRComment creates the "step pr comment" command object
 func NewCmdStepPRComment(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	options := &StepPRCommentOptions{
 		StepPROptions: StepPROptions{
@@ -54,9 +52,8 @@ func NewCmdStepPRComment(f cmdutil.Factory, out io.Writer, errOut io.Writer) *co
 	}
 
 	cmd.Flags().StringVarP(&options.Flags.Comment, "comment", "c", "", "comment to add to the Pull Request")
-	cmd.Flags().StringVarP(&options.Flags.Owner, "owner", "o", "", "git organisation / owner")
-	cmd.Flags().StringVarP(&options.Flags.Repository, "repository", "r", "", "git repository")
-	cmd.Flags().StringVarP(&options.Flags.PR, "pull-request", "p", "", "git pull request number")
+	cmd.Flags().StringVarP(&options.Flags.FromFile, "from-file", "", "", "read the comment body from this file instead of --comment")
+	addStepPRFlags(cmd, &options.Flags.Owner, &options.Flags.Repository, &options.Flags.PR, &options.Flags.PRBranch)
 
 	options.addCommonFlags(cmd)
 
@@ -65,48 +62,18 @@ func NewCmdStepPRComment(f cmdutil.Factory, out io.Writer, errOut io.Writer) *co
 
 // Run implements this command
 func (o *StepPRCommentOptions) Run() error {
-	if o.Flags.PR == "" {
-		return fmt.Errorf("no pull request number provided")
-	}
-	if o.Flags.Owner == "" {
-		return fmt.Errorf("no git owner provided")
-	}
-	if o.Flags.Repository == "" {
-		return fmt.Errorf("no git repository provided")
-	}
-	if o.Flags.Comment == "" {
-		return fmt.Errorf("no comment provided")
-	}
-
-	authConfigSvc, err := o.Factory.CreateGitAuthConfigService()
+	body, err := bodyFromFlagOrFile(o.Flags.Comment, o.Flags.FromFile)
 	if err != nil {
 		return err
 	}
-
-	gitInfo, err := gits.GetGitInfo("")
-	if err != nil {
-		return err
-	}
-	gitKind, err := o.GitServerKind(gitInfo)
-	if err != nil {
-		return err
-	}
-
-	provider, err := gitInfo.PickOrCreateProvider(authConfigSvc, "user name to submit comment as", o.BatchMode, gitKind)
-	if err != nil {
-		return err
+	if body == "" {
+		return fmt.Errorf("no comment provided. Pass --comment or --from-file")
 	}
 
-	prNumber, err := strconv.Atoi(o.Flags.PR)
+	provider, pr, err := o.resolveProviderAndPR(o.Flags.Owner, o.Flags.Repository, o.Flags.PR, o.Flags.PRBranch)
 	if err != nil {
 		return err
 	}
 
-	pr := gits.GitPullRequest{
-		Repo:   o.Flags.Repository,
-		Owner:  o.Flags.Owner,
-		Number: &prNumber,
-	}
-
-	return provider.AddPRComment(&pr, o.Flags.Comment)
+	return provider.AddPRComment(pr, body)
 }
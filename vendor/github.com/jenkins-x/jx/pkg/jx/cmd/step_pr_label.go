@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+)
+
+// StepPRLabelOptions contains the command line flags for "jx step pr label"
+type StepPRLabelOptions struct {
+	StepPROptions
+	Flags StepPRLabelFlags
+}
+
+// StepPRLabelFlags are the flags for "jx step pr label"
+type StepPRLabelFlags struct {
+	Add        []string
+	Remove     []string
+	Owner      string
+	Repository string
+	PR         string
+	PRBranch   string
+}
+
+// NewCmdStepPRLabel creates the "step pr label" command object
+func NewCmdStepPRLabel(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepPRLabelOptions{
+		StepPROptions: StepPROptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "pipeline step pr label",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&options.Flags.Add, "add", "a", nil, "a label to add to the Pull Request. Can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&options.Flags.Remove, "remove", "", nil, "a label to remove from the Pull Request. Can be specified multiple times")
+	addStepPRFlags(cmd, &options.Flags.Owner, &options.Flags.Repository, &options.Flags.PR, &options.Flags.PRBranch)
+
+	options.addCommonFlags(cmd)
+
+	return cmd
+}
+
+// Run implements this command
+func (o *StepPRLabelOptions) Run() error {
+	if len(o.Flags.Add) == 0 && len(o.Flags.Remove) == 0 {
+		return fmt.Errorf("no labels provided. Pass --add and/or --remove")
+	}
+
+	provider, pr, err := o.resolveProviderAndPR(o.Flags.Owner, o.Flags.Repository, o.Flags.PR, o.Flags.PRBranch)
+	if err != nil {
+		return err
+	}
+
+	if len(o.Flags.Add) > 0 {
+		if err := provider.AddPRLabels(pr, o.Flags.Add); err != nil {
+			return err
+		}
+	}
+	if len(o.Flags.Remove) > 0 {
+		if err := provider.RemovePRLabels(pr, o.Flags.Remove); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	reviewStateApprove        = "APPROVE"
+	reviewStateRequestChanges = "REQUEST_CHANGES"
+	reviewStateComment        = "COMMENT"
+)
+
+var reviewStates = []string{reviewStateApprove, reviewStateRequestChanges, reviewStateComment}
+
+// StepPRReviewOptions contains the command line flags for "jx step pr review"
+type StepPRReviewOptions struct {
+	StepPROptions
+	Flags StepPRReviewFlags
+}
+
+// StepPRReviewFlags are the flags for "jx step pr review"
+type StepPRReviewFlags struct {
+	State      string
+	Comment    string
+	FromFile   string
+	Owner      string
+	Repository string
+	PR         string
+	PRBranch   string
+}
+
+// NewCmdStepPRReview creates the "step pr review" command object
+func NewCmdStepPRReview(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepPRReviewOptions{
+		StepPROptions: StepPROptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "pipeline step pr review",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Flags.State, "state", "s", "", "the review state to submit. Possible values: APPROVE, REQUEST_CHANGES, COMMENT. Required - there is no default, so a missing or mistyped --state fails loudly rather than silently approving the PR")
+	cmd.Flags().StringVarP(&options.Flags.Comment, "comment", "c", "", "the review body")
+	cmd.Flags().StringVarP(&options.Flags.FromFile, "from-file", "", "", "read the review body from this file instead of --comment")
+	addStepPRFlags(cmd, &options.Flags.Owner, &options.Flags.Repository, &options.Flags.PR, &options.Flags.PRBranch)
+
+	options.addCommonFlags(cmd)
+
+	return cmd
+}
+
+// Run implements this command
+func (o *StepPRReviewOptions) Run() error {
+	state := o.Flags.State
+	if state == "" {
+		return util.MissingOption("state")
+	}
+	if util.StringArrayIndex(reviewStates, state) < 0 {
+		return util.InvalidOption("state", state, reviewStates)
+	}
+
+	body, err := bodyFromFlagOrFile(o.Flags.Comment, o.Flags.FromFile)
+	if err != nil {
+		return err
+	}
+	if body == "" && state != reviewStateApprove {
+		return fmt.Errorf("no comment provided. Pass --comment or --from-file when --state is %s or %s", reviewStateRequestChanges, reviewStateComment)
+	}
+
+	provider, pr, err := o.resolveProviderAndPR(o.Flags.Owner, o.Flags.Repository, o.Flags.PR, o.Flags.PRBranch)
+	if err != nil {
+		return err
+	}
+
+	return provider.CreatePRReview(pr, state, body)
+}
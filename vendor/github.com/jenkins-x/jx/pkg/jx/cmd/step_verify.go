@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stepVerifyLong = templates.LongDesc(`
+		Steps to verify that a Jenkins X install or a change to the import/pipeline tooling
+		still works end to end
+`)
+)
+
+// StepVerifyOptions contains the command line flags shared by all "jx step verify" sub-commands
+type StepVerifyOptions struct {
+	StepOptions
+}
+
+// NewCmdStepVerify creates the "step verify" command object
+func NewCmdStepVerify(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepVerifyOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "pipeline step verify",
+		Long:  stepVerifyLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdStepVerifyBehavior(f, out, errOut))
+
+	return cmd
+}
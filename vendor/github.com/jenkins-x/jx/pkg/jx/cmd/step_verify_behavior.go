@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const stepVerifyBehaviorPollInterval = 10 * time.Second
+
+var (
+	stepVerifyBehaviorLong = templates.LongDesc(`
+		Smoke tests the import/pipeline chunk end to end: clones a canary repository, imports it
+		and waits for the resulting pipeline to go green. This is intended to be run by the
+		release pipeline after any change to 'jx import' so that a regression in template
+		defaulting, webhook registration or the Jenkins credential setup is caught immediately
+		rather than by the next user to run 'jx import'.
+`)
+
+	stepVerifyBehaviorExample = templates.Examples(`
+		# Import the canary repo and wait up to the default 30m for a green build
+		jx step verify behavior --source-git-url https://github.com/jenkins-x/jx-canary.git --branch master
+
+		# Reuse an already imported canary repo rather than re-importing it
+		jx step verify behavior --source-git-url https://github.com/jenkins-x/jx-canary.git --branch master --no-import
+	`)
+)
+
+// StepVerifyBehaviorOptions the command line options for "jx step verify behavior"
+type StepVerifyBehaviorOptions struct {
+	StepVerifyOptions
+
+	SourceGitURL      string
+	Branch            string
+	Timeout           time.Duration
+	NoImport          bool
+	CredentialsSecret string
+	LogLines          int
+}
+
+// NewCmdStepVerifyBehavior creates the "step verify behavior" command object
+func NewCmdStepVerifyBehavior(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepVerifyBehaviorOptions{
+		StepVerifyOptions: StepVerifyOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "behavior",
+		Short:   "Imports a canary repository and asserts its pipeline goes green",
+		Long:    stepVerifyBehaviorLong,
+		Example: stepVerifyBehaviorExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.SourceGitURL, "source-git-url", "", "", "The git URL of the canary repository to import and run a pipeline for")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "", "master", "The branch of the canary repository to import and run a pipeline for")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "", 30*time.Minute, "How long to wait for the pipeline to go green before giving up")
+	cmd.Flags().BoolVarP(&options.NoImport, "no-import", "", false, "Reuse an already imported canary repository instead of importing it again")
+	cmd.Flags().StringVarP(&options.CredentialsSecret, "credentials-secret", "", "", "The name of the Secret holding the pipeline git credentials to use, so CI can run this non-interactively instead of being prompted")
+	cmd.Flags().IntVarP(&options.LogLines, "log-lines", "", 200, "How many trailing log lines to dump when the pipeline fails")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepVerifyBehaviorOptions) Run() error {
+	if o.SourceGitURL == "" {
+		return util.MissingOption("source-git-url")
+	}
+
+	gitInfo, err := gits.ParseGitURL(o.SourceGitURL)
+	if err != nil {
+		return fmt.Errorf("Failed to parse git URL %s due to: %s", o.SourceGitURL, err)
+	}
+
+	if !o.NoImport {
+		err = o.importCanaryRepo()
+		if err != nil {
+			return fmt.Errorf("Failed to import canary repository %s: %s", o.SourceGitURL, err)
+		}
+	}
+
+	activity, err := o.waitForPipelineActivity(gitInfo.Organisation, gitInfo.Name, o.Branch)
+	if err != nil {
+		o.dumpActivityLogs(activity)
+		return err
+	}
+
+	o.Printf("Pipeline for %s succeeded\n", util.ColorInfo(gitInfo.Name))
+	return nil
+}
+
+// importCanaryRepo clones the canary repo into a tempdir and runs the same import flow as
+// 'jx import', reusing whatever git credentials --credentials-secret points at so this can run
+// unattended
+func (o *StepVerifyBehaviorOptions) importCanaryRepo() error {
+	gitInfo, err := gits.ParseGitURL(o.SourceGitURL)
+	if err != nil {
+		return fmt.Errorf("Failed to parse git URL %s due to: %s", o.SourceGitURL, err)
+	}
+	dir, err := util.CreateUniqueDirectory(os.TempDir(), gitInfo.Name, util.MaximumNewDirectoryAttempts)
+	if err != nil {
+		return err
+	}
+
+	importOptions := &ImportOptions{
+		CommonOptions: o.CommonOptions,
+		RepoURL:       o.SourceGitURL,
+		Dir:           dir,
+		DisableDraft:  false,
+		Credentials:   o.CredentialsSecret,
+	}
+	importOptions.BatchMode = true
+	return importOptions.Run()
+}
+
+// waitForPipelineActivity polls the PipelineActivity for owner/repo/branch until it succeeds,
+// fails or the timeout elapses
+func (o *StepVerifyBehaviorOptions) waitForPipelineActivity(owner string, repo string, branch string) (*v1.PipelineActivity, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	name := kube.ToValidName(fmt.Sprintf("%s-%s-%s", owner, repo, branch))
+
+	deadline := time.Now().Add(o.Timeout)
+	for {
+		activity, err := jxClient.JenkinsV1().PipelineActivities(ns).Get(name, metav1.GetOptions{})
+		if err == nil {
+			switch activity.Spec.Status {
+			case v1.ActivityStatusTypeSucceeded:
+				return activity, nil
+			case v1.ActivityStatusTypeFailed, v1.ActivityStatusTypeError:
+				return activity, fmt.Errorf("pipeline %s finished with status %s", name, activity.Spec.Status)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return activity, fmt.Errorf("timed out after %s waiting for pipeline %s to succeed", o.Timeout, name)
+		}
+		time.Sleep(stepVerifyBehaviorPollInterval)
+	}
+}
+
+// dumpActivityLogs prints the last LogLines lines of the build log for activity to help
+// diagnose why the smoke test failed
+func (o *StepVerifyBehaviorOptions) dumpActivityLogs(activity *v1.PipelineActivity) {
+	if activity == nil {
+		return
+	}
+	o.Printf(util.ColorWarning("WARNING: pipeline %s did not succeed, last log lines:\n"), activity.Name)
+	lines, err := o.tailBuildLogLines(activity, o.LogLines)
+	if err != nil {
+		o.Printf("Failed to fetch build logs for %s: %s\n", activity.Name, err)
+		return
+	}
+	for _, line := range lines {
+		o.Printf("%s\n", line)
+	}
+}
+
+// tailBuildLogLines returns the last n lines of the build pod (or Jenkins job) log referenced by
+// activity's build log URL
+func (o *StepVerifyBehaviorOptions) tailBuildLogLines(activity *v1.PipelineActivity, n int) ([]string, error) {
+	return kube.TailBuildLogLines(o.kubeClient, activity.Spec.BuildLogsURL, n)
+}
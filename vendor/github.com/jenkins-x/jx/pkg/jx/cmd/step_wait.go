@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stepWaitLong = templates.LongDesc(`
+		Blocks a pipeline stage until an external event arrives, so a stage can gate on work done
+		outside the pipeline (such as a CVE scan verdict) without polling it directly
+`)
+)
+
+// StepWaitOptions contains the command line flags shared by all "jx step wait" sub-commands
+type StepWaitOptions struct {
+	StepOptions
+}
+
+// NewCmdStepWait creates the "step wait" command object
+func NewCmdStepWait(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepWaitOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "pipeline step wait",
+		Long:  stepWaitLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdStepWaitCVEEvent(f, out, errOut))
+
+	return cmd
+}
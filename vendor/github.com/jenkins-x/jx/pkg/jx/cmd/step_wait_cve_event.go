@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/cloudevent"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const stepWaitCVEEventPollInterval = 10 * time.Second
+
+var (
+	stepWaitCVEEventLong = templates.LongDesc(`
+		Blocks until a CloudEvents '` + cloudevent.CVEScanCompletedType + `' event for --subject is
+		published to the events sink, so a pipeline stage can gate on a CVE scan verdict published
+		by 'jx scan image --events-sink' instead of re-running or polling the scanner directly.
+
+		The event is inspected, not just waited for: the stage fails if the scan's policy
+		evaluation failed, or if --fail-on is set and the scan found a vulnerability at or above
+		that severity.
+`)
+
+	stepWaitCVEEventExample = templates.Examples(`
+		# Wait up to the default 10m for the scan of this image to publish its verdict
+		jx step wait cve-event --subject sha256:abcdef...
+
+		# Also fail the stage if a high or critical vulnerability was found
+		jx step wait cve-event --subject sha256:abcdef... --fail-on high
+
+		# Use a custom sink and timeout
+		jx step wait cve-event --subject sha256:abcdef... --sink http://cve-events.jx.svc.cluster.local --timeout 30m
+	`)
+)
+
+// StepWaitCVEEventOptions the command line options for "jx step wait cve-event"
+type StepWaitCVEEventOptions struct {
+	StepWaitOptions
+
+	Subject string
+	Sink    string
+	Timeout time.Duration
+	FailOn  string
+}
+
+// NewCmdStepWaitCVEEvent creates the "step wait cve-event" command object
+func NewCmdStepWaitCVEEvent(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepWaitCVEEventOptions{
+		StepWaitOptions: StepWaitOptions{
+			StepOptions: StepOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "cve-event",
+		Short:   "Waits for a CVE scan completed CloudEvent to be published for an image digest",
+		Long:    stepWaitCVEEventLong,
+		Example: stepWaitCVEEventExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Subject, "subject", "", "", "The image digest to wait for a scan completed event for")
+	cmd.Flags().StringVarP(&options.Sink, "sink", "", "", fmt.Sprintf("URL of the events sink to poll. Defaults to the %s environment variable", cloudevent.EnvSinkURL))
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "", 10*time.Minute, "How long to wait for the event before giving up")
+	cmd.Flags().StringVarP(&options.FailOn, "fail-on", "", "", "Fail the command if the scan found a vulnerability at or above this severity. Possible values: "+strings.Join(scanImageSeverities, ", "))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepWaitCVEEventOptions) Run() error {
+	if o.Subject == "" {
+		return util.MissingOption("subject")
+	}
+	if o.FailOn != "" {
+		if _, ok := scanSeverityRank[o.FailOn]; !ok {
+			return util.InvalidOption("fail-on", o.FailOn, scanImageSeverities)
+		}
+	}
+	sinkURL := cloudevent.SinkURL(o.Sink)
+	if sinkURL == "" {
+		return util.MissingOption("sink")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	deadline := time.Now().Add(o.Timeout)
+	for {
+		events, err := cloudevent.QueryBySubject(client, sinkURL, o.Subject)
+		if err == nil {
+			for _, event := range events {
+				if event.Type == cloudevent.CVEScanCompletedType {
+					return o.gateOnVerdict(event)
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for a %s event for %s", o.Timeout, cloudevent.CVEScanCompletedType, o.Subject)
+		}
+		time.Sleep(stepWaitCVEEventPollInterval)
+	}
+}
+
+// gateOnVerdict inspects the scan summary carried by event, failing the step if the policy
+// evaluation failed or, when --fail-on is set, if a vulnerability at or above that severity was
+// found - otherwise a stage gated on this step would proceed identically whether the scan passed
+// or found critical vulnerabilities
+func (o *StepWaitCVEEventOptions) gateOnVerdict(event cloudevent.Event) error {
+	data, err := event.ScanCompletedData()
+	if err != nil {
+		return fmt.Errorf("received a %s event for %s but couldn't read its scan summary: %v", event.Type, o.Subject, err)
+	}
+
+	if strings.EqualFold(data.PolicyStatus, "fail") {
+		return fmt.Errorf("CVE scan of %s failed its policy evaluation (status %s)", o.Subject, data.PolicyStatus)
+	}
+
+	if o.FailOn != "" {
+		worst := "negligible"
+		found := false
+		for severity, count := range data.VulnerabilityCounts {
+			if count > 0 {
+				found = true
+			}
+			if count > 0 && scanSeverityRank[strings.ToLower(severity)] > scanSeverityRank[worst] {
+				worst = strings.ToLower(severity)
+			}
+		}
+		if found && scanSeverityRank[worst] >= scanSeverityRank[o.FailOn] {
+			return fmt.Errorf("scan of %s found a %s severity vulnerability which is at or above the --fail-on threshold of %s", o.Subject, worst, o.FailOn)
+		}
+	}
+
+	o.Printf("received %s event for %s: policy evaluation %s\n", util.ColorInfo(event.Type), util.ColorInfo(o.Subject), util.ColorInfo(data.PolicyStatus))
+	return nil
+}
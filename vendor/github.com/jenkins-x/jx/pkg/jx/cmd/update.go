@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateLong = templates.LongDesc(`
+		Updates an existing resource, such as addon configuration, in your Jenkins X install
+`)
+)
+
+// UpdateOptions contains the command line flags shared by all "jx update" sub-commands
+type UpdateOptions struct {
+	CommonOptions
+}
+
+// NewCmdUpdate creates the "update" command object
+func NewCmdUpdate(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpdateOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update resources",
+		Long:  updateLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdUpdateAddon(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *UpdateOptions) Run() error {
+	return o.Cmd.Help()
+}
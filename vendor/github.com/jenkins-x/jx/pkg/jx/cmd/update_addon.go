@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateAddonLong = templates.LongDesc(`
+		Updates the configuration of an installed addon
+`)
+)
+
+// UpdateAddonOptions contains the command line flags shared by all "jx update addon" sub-commands
+type UpdateAddonOptions struct {
+	UpdateOptions
+}
+
+// NewCmdUpdateAddon creates the "update addon" command object
+func NewCmdUpdateAddon(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpdateAddonOptions{
+		UpdateOptions: UpdateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "addon",
+		Short: "update addon configuration",
+		Long:  updateAddonLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdUpdateAddonAnchorePolicy(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *UpdateAddonOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/log"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateAddonAnchorePolicyLong = templates.LongDesc(`
+		Uploads a new Anchore policy bundle (or re-activates an already uploaded one by ID) and
+		makes it the active policy for the team's Anchore addon, so future 'jx scan image' and
+		'jx get cve' checks are evaluated against it.
+`)
+
+	updateAddonAnchorePolicyExample = templates.Examples(`
+		# Upload and activate a new policy bundle
+		jx update addon anchore-policy --policy-bundle policy.json
+
+		# Re-activate a policy bundle that was already uploaded
+		jx update addon anchore-policy --policy-id 2c53a13c-1765-11e8-82ef-23527761d060
+	`)
+)
+
+// UpdateAddonAnchorePolicyOptions the options for "jx update addon anchore-policy"
+type UpdateAddonAnchorePolicyOptions struct {
+	UpdateAddonOptions
+
+	Namespace    string
+	PolicyBundle string
+	PolicyID     string
+}
+
+// NewCmdUpdateAddonAnchorePolicy creates the "update addon anchore-policy" command object
+func NewCmdUpdateAddonAnchorePolicy(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpdateAddonAnchorePolicyOptions{
+		UpdateAddonOptions: UpdateAddonOptions{
+			UpdateOptions: UpdateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "anchore-policy",
+		Short:   "Upload and activate an Anchore policy bundle",
+		Long:    updateAddonAnchorePolicyLong,
+		Example: updateAddonAnchorePolicyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", defaultAnchoreNamespace, "The namespace the Anchore addon is installed into")
+	cmd.Flags().StringVarP(&options.PolicyBundle, "policy-bundle", "", "", "A local Anchore policy bundle JSON file to upload and activate")
+	cmd.Flags().StringVarP(&options.PolicyID, "policy-id", "", "", "The ID of an already uploaded policy bundle to re-activate")
+	return cmd
+}
+
+// Run implements this command
+func (o *UpdateAddonAnchorePolicyOptions) Run() error {
+	if o.PolicyBundle == "" && o.PolicyID == "" {
+		return fmt.Errorf("either --policy-bundle or --policy-id must be specified")
+	}
+
+	_, _, err := o.KubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot connect to kubernetes cluster: %v", err)
+	}
+
+	server, auth, err := o.CommonOptions.getAddonAuthByKind(kube.ValueKindCVE)
+	if err != nil {
+		return fmt.Errorf("error getting anchore engine auth details, try running `jx create addon anchore`: %v", err)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	policyID, err := applyAnchorePolicy(client, server.URL, auth.Username, auth.Password, o.PolicyBundle, o.PolicyID)
+	if err != nil {
+		return fmt.Errorf("failed to apply Anchore policy: %v", err)
+	}
+
+	err = annotateServiceWithAnchorePolicy(o.kubeClient, o.Namespace, anchoreServiceName, policyID)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("activated Anchore policy %s\n", policyID)
+	return nil
+}
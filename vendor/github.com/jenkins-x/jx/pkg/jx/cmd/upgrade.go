@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	upgradeLong = templates.LongDesc(`
+		Upgrades resources used by Jenkins X, such as the pinned versions of the CLI tools it
+		installs.
+`)
+)
+
+// UpgradeOptions is the parent of the "jx upgrade" sub-commands
+type UpgradeOptions struct {
+	CommonOptions
+}
+
+// NewCmdUpgrade creates the "upgrade" command object
+func NewCmdUpgrade(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpgradeOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrades resources used by Jenkins X",
+		Long:  upgradeLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(NewCmdUpgradeVersions(f, out, errOut))
+
+	return cmd
+}
+
+// Run implements this command
+func (o *UpgradeOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	cmdutil "github.com/jenkins-x/jx/pkg/jx/cmd/util"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/spf13/cobra"
+)
+
+// DefaultVersionStreamURL is the git repo that 'jx upgrade versions' pulls pinned package
+// versions from when --version-stream-url isn't overridden
+const DefaultVersionStreamURL = "https://github.com/jenkins-x/jenkins-x-versions.git"
+
+var (
+	upgradeVersionsLong = templates.LongDesc(`
+		Pulls the latest pinned versions, checksums and download URLs for the CLI tools jx installs
+		(kubectl, helm, kops, ...) from a version stream git repo, so that 'jx install' and
+		'jx create cluster' become reproducible instead of always grabbing whatever upstream
+		happens to have published most recently.
+`)
+
+	upgradeVersionsExample = templates.Examples(`
+		# Pull the latest versions from the default version stream
+		jx upgrade versions
+
+		# Lock to a specific tag of a team's own version stream fork
+		jx upgrade versions --version-stream-url https://github.com/myorg/jx-versions.git --version-stream-ref v1.2.3
+	`)
+)
+
+// UpgradeVersionsOptions the command line options for "jx upgrade versions"
+type UpgradeVersionsOptions struct {
+	UpgradeOptions
+
+	VersionStreamURL string
+	VersionStreamRef string
+}
+
+// NewCmdUpgradeVersions creates the "upgrade versions" command object
+func NewCmdUpgradeVersions(f cmdutil.Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &UpgradeVersionsOptions{
+		UpgradeOptions: UpgradeOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "versions",
+		Short:   "Upgrades the pinned versions of the CLI tools jx installs",
+		Long:    upgradeVersionsLong,
+		Example: upgradeVersionsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			cmdutil.CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.VersionStreamURL, "version-stream-url", "", DefaultVersionStreamURL, "The git URL of the version stream to pull pinned tool versions from")
+	cmd.Flags().StringVarP(&options.VersionStreamRef, "version-stream-ref", "", "master", "The git ref of the version stream to check out")
+	return cmd
+}
+
+// Run implements this command
+func (o *UpgradeVersionsOptions) Run() error {
+	dir, err := versionstream.CloneOrPullVersionsRepo(o.VersionStreamURL, o.VersionStreamRef, versionStreamDir())
+	if err != nil {
+		return err
+	}
+	o.Printf("Updated version stream %s at %s\n", util.ColorInfo(o.VersionStreamURL), util.ColorInfo(dir))
+	return nil
+}
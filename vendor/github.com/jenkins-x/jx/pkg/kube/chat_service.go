@@ -0,0 +1,78 @@
+package kube
+
+import (
+	"fmt"
+	"io"
+
+	jv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnsureChatServiceExistsForHost ensures that a ChatService resource exists in ns describing the
+// chat server at url, creating or updating it as required, mirroring EnsureGitServiceExistsForHost.
+// This lets team members auto-discover the chat server from the cluster instead of each having
+// to run `jx create chat server` locally.
+func EnsureChatServiceExistsForHost(jxClient versioned.Interface, ns string, kind string, name string, url string, out io.Writer) error {
+	if kind == "" || url == "" {
+		return nil
+	}
+	resourceName := ToValidName(name)
+	chatServices := jxClient.JenkinsV1().ChatServices(ns)
+	svc, err := chatServices.Get(resourceName, metav1.GetOptions{})
+	if err == nil {
+		if svc.Spec.URL == url && svc.Spec.Kind == kind {
+			return nil
+		}
+		svc.Spec.URL = url
+		svc.Spec.Kind = kind
+		_, err = chatServices.Update(svc)
+		return err
+	}
+
+	svc = &jv1.ChatService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: resourceName,
+		},
+		Spec: jv1.ChatServiceSpec{
+			Name: name,
+			URL:  url,
+			Kind: kind,
+		},
+	}
+	_, err = chatServices.Create(svc)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Registered ChatService %s at %s in namespace %s\n", name, url, ns)
+	return nil
+}
+
+// DeleteChatServiceForHost removes the ChatService resource named name from ns, if present. It
+// silently succeeds if the resource does not exist, since not every chat server was necessarily
+// registered in the cluster (e.g. one created before this CRD existed)
+func DeleteChatServiceForHost(jxClient versioned.Interface, ns string, name string) error {
+	resourceName := ToValidName(name)
+	err := jxClient.JenkinsV1().ChatServices(ns).Delete(resourceName, &metav1.DeleteOptions{})
+	if err != nil && !kubeerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// GetChatServiceKind returns the kind of chat service (e.g. slack, mattermost, rocketchat,
+// hipchat) registered for the given URL in ns, or "" if none is registered, mirroring
+// GetGitServiceKind's role for git servers
+func GetChatServiceKind(jxClient versioned.Interface, ns string, url string) (string, error) {
+	list, err := jxClient.JenkinsV1().ChatServices(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, svc := range list.Items {
+		if svc.Spec.URL == url {
+			return svc.Spec.Kind, nil
+		}
+	}
+	return "", nil
+}
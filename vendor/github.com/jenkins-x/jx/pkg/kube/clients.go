@@ -16,3 +16,12 @@ func CreateClient(kubeconfig *string) (*kubernetes.Clientset, error) {
 	// create the clientset
 	return kubernetes.NewForConfig(config)
 }
+
+// CurrentContextName returns the name of the currently active kubeconfig context
+func CurrentContextName() (string, error) {
+	config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		return "", err
+	}
+	return config.CurrentContext, nil
+}
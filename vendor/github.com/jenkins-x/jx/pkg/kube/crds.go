@@ -35,6 +35,20 @@ func RegisterGitServiceCRD(apiClient *apiextensionsclientset.Clientset) error {
 	return registerCRD(apiClient, name, names)
 }
 
+// RegisterChatServiceCRD ensures that the CRD is registered for ChatServices
+func RegisterChatServiceCRD(apiClient *apiextensionsclientset.Clientset) error {
+	name := "chatservices." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "ChatService",
+		ListKind:   "ChatServiceList",
+		Plural:     "chatservices",
+		Singular:   "chatservice",
+		ShortNames: []string{"chats"},
+	}
+
+	return registerCRD(apiClient, name, names)
+}
+
 // RegisterPipelineActivityCRD ensures that the CRD is registered for PipelineActivity
 func RegisterPipelineActivityCRD(apiClient *apiextensionsclientset.Clientset) error {
 	name := "pipelineactivities." + jenkinsio.GroupName
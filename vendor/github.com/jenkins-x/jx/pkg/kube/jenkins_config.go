@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	corev1 "k8s.io/api/core/v1"
+	"gopkg.in/yaml.v2"
+)
+
+// jenkinsGitServersConfigMapKey is the data key under which the list of configured git servers
+// is stored in the jenkins-x ConfigMap
+const jenkinsGitServersConfigMapKey = "gitServers"
+
+// JenkinsGitServerAuthMode is how a Jenkins pipeline credential authenticates against a git server
+type JenkinsGitServerAuthMode string
+
+const (
+	// JenkinsGitServerAuthModeToken means the credential is a username + API token pair
+	JenkinsGitServerAuthModeToken JenkinsGitServerAuthMode = "token"
+	// JenkinsGitServerAuthModeSSH means the credential is an SSH deploy key
+	JenkinsGitServerAuthModeSSH JenkinsGitServerAuthMode = "ssh"
+)
+
+// JenkinsGitServer is one entry in the jenkins-x ConfigMap's list of configured git servers
+type JenkinsGitServer struct {
+	Kind         string                   `yaml:"kind"`
+	Name         string                   `yaml:"name"`
+	URL          string                   `yaml:"url"`
+	CredentialID string                   `yaml:"credentialId"`
+	AuthMode     JenkinsGitServerAuthMode `yaml:"authMode"`
+}
+
+// UpdateJenkinsGitServers ensures cm has an entry for server pointing at credentialID with the
+// given authMode, adding or replacing it as needed. It returns true if cm.Data was changed, so
+// the caller knows whether the ConfigMap needs to be persisted and Jenkins reloaded.
+func UpdateJenkinsGitServers(cm *corev1.ConfigMap, server *auth.AuthServer, userAuth *auth.UserAuth, credentialID string, authMode JenkinsGitServerAuthMode) (bool, error) {
+	servers, err := loadJenkinsGitServers(cm)
+	if err != nil {
+		return false, err
+	}
+
+	entry := JenkinsGitServer{
+		Kind:         server.Kind,
+		Name:         server.Name,
+		URL:          server.URL,
+		CredentialID: credentialID,
+		AuthMode:     authMode,
+	}
+
+	found := false
+	updated := false
+	for i := range servers {
+		if servers[i].Name == entry.Name && servers[i].Kind == entry.Kind {
+			found = true
+			if servers[i] != entry {
+				servers[i] = entry
+				updated = true
+			}
+		}
+	}
+	if !found {
+		servers = append(servers, entry)
+		updated = true
+	}
+	if !updated {
+		return false, nil
+	}
+
+	data, err := yaml.Marshal(servers)
+	if err != nil {
+		return false, fmt.Errorf("Failed to marshal Jenkins git servers: %s", err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[jenkinsGitServersConfigMapKey] = string(data)
+	return true, nil
+}
+
+func loadJenkinsGitServers(cm *corev1.ConfigMap) ([]JenkinsGitServer, error) {
+	servers := []JenkinsGitServer{}
+	if cm.Data == nil {
+		return servers, nil
+	}
+	text := cm.Data[jenkinsGitServersConfigMapKey]
+	if text == "" {
+		return servers, nil
+	}
+	err := yaml.Unmarshal([]byte(text), &servers)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal Jenkins git servers: %s", err)
+	}
+	return servers, nil
+}
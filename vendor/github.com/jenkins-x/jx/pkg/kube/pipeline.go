@@ -0,0 +1,54 @@
+package kube
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ToValidName converts name into a value that's safe to use as a Kubernetes resource name:
+// lower-cased, with any run of characters outside [a-z0-9-] collapsed to a single "-"
+func ToValidName(name string) string {
+	lower := strings.ToLower(name)
+	return invalidNameChars.ReplaceAllString(lower, "-")
+}
+
+// TailBuildLogLines returns the last n lines of the build log found at buildLogsURL. Currently
+// only http(s) log URLs (as stored on PipelineActivity.Spec.BuildLogsURL) are supported; client
+// is accepted so that future pod-log based lookups can be added without changing callers
+func TailBuildLogLines(client kubernetes.Interface, buildLogsURL string, n int) ([]string, error) {
+	if buildLogsURL == "" {
+		return nil, fmt.Errorf("no build log URL available")
+	}
+	if !strings.HasPrefix(buildLogsURL, "http://") && !strings.HasPrefix(buildLogsURL, "https://") {
+		return nil, fmt.Errorf("unsupported build log URL %s", buildLogsURL)
+	}
+
+	resp, err := http.Get(buildLogsURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s returned status %d", buildLogsURL, resp.StatusCode)
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -0,0 +1,66 @@
+package kube
+
+// Labels, annotations and secret data keys used to mark and read the Secrets that back pipeline
+// credentials (git, addons, CVE scanners, ...) and other jx managed resources.
+const (
+	LabelKind            = "jenkins.io/kind"
+	LabelCredentialsType = "jenkins.io/credentials-type"
+	LabelServiceKind     = "jenkins.io/service-kind"
+	LabelCreatedBy       = "jenkins.io/created-by"
+	LabelDevPodName      = "jenkins.io/devpod"
+	LabelDevPodUsername  = "jenkins.io/devpod-username"
+	LabelPodTemplate     = "jenkins.io/pod-template"
+
+	AnnotationURL                    = "jenkins.io/url"
+	AnnotationName                   = "jenkins.io/name"
+	AnnotationExpose                 = "fabric8.io/expose"
+	AnnotationWorkingDir             = "jenkins.io/working-dir"
+	AnnotationCredentialsDescription = "jenkins.io/credentials-description"
+	// AnnotationServiceAccountTokenSecret names the SecretTypeServiceAccountToken Secret that
+	// backs a credentials Secret, so a Jenkins pipeline credential binding can resolve it
+	// deterministically instead of guessing the ServiceAccount's auto-generated token Secret name
+	AnnotationServiceAccountTokenSecret = "jenkins.io/service-account-token-secret"
+	// AnnotationAnchorePolicyID records the Anchore policy bundle ID activated for a team, so
+	// 'jx get cve' and pipeline policy gates evaluate images against the same bundle the admin
+	// selected at install/update time rather than Anchore's default policy
+	AnnotationAnchorePolicyID = "jenkins.io/anchore-policy-id"
+
+	ValueKindGit     = "git"
+	ValueKindAddon   = "addon"
+	ValueKindCVE     = "cve"
+	ValueKindWebhook = "webhook"
+
+	ValueCreatedByJX = "jx"
+
+	// ValueCredentialTypeUsernamePassword marks a Secret holding a plain username/password pair
+	ValueCredentialTypeUsernamePassword = "usernamePassword"
+	// ValueCredentialTypeSSHPrivateKey marks a Secret holding an SSH private key (and optional
+	// passphrase) used to authenticate git operations over SSH
+	ValueCredentialTypeSSHPrivateKey = "sshPrivateKey"
+	// ValueCredentialTypeToken marks a Secret holding a personal access token used in place of a
+	// password, e.g. a GitHub/GitLab PAT
+	ValueCredentialTypeToken = "token"
+	// ValueCredentialTypeGitHubApp marks a Secret holding the identity of a GitHub App
+	// installation (app ID, installation ID and private key) used in place of user credentials
+	ValueCredentialTypeGitHubApp = "githubApp"
+
+	SecretDataUsername = "username"
+	SecretDataPassword = "password"
+	// SecretDataSSHPrivateKey is the PEM encoded SSH private key for a ValueCredentialTypeSSHPrivateKey Secret
+	SecretDataSSHPrivateKey = "sshPrivateKey"
+	// SecretDataSSHPassphrase is the optional passphrase protecting SecretDataSSHPrivateKey
+	SecretDataSSHPassphrase = "passphrase"
+	// SecretDataKnownHosts is the known_hosts data stored alongside an SSH deploy key credential
+	// so git clone can verify the git server's host key
+	SecretDataKnownHosts = "known_hosts"
+	// SecretDataToken is the personal access token for a ValueCredentialTypeToken Secret
+	SecretDataToken = "token"
+	// SecretDataGitHubAppID is the numeric GitHub App ID for a ValueCredentialTypeGitHubApp Secret
+	SecretDataGitHubAppID = "githubAppID"
+	// SecretDataGitHubInstallationID is the numeric GitHub App installation ID for a
+	// ValueCredentialTypeGitHubApp Secret
+	SecretDataGitHubInstallationID = "githubInstallationID"
+	// SecretDataWebhookSecret is the HMAC secret for a ValueKindWebhook Secret, used to sign and
+	// validate webhook deliveries for a single repository
+	SecretDataWebhookSecret = "secret"
+)
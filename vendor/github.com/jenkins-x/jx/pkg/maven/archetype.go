@@ -1,11 +1,6 @@
 package maven
 
 import (
-	"bytes"
-	"encoding/xml"
-	"io/ioutil"
-	"net/http"
-	"path/filepath"
 	"strings"
 
 	"fmt"
@@ -23,6 +18,10 @@ type ArtifactVersions struct {
 	ArtifactId  string
 	Description string
 	Versions    []string
+
+	// Catalog is the name of the CatalogSource that contributed Description, so the survey can
+	// show the user where an archetype's details came from when multiple catalogs are merged
+	Catalog string
 }
 
 type GroupArchectypes struct {
@@ -39,6 +38,9 @@ type ArtifactData struct {
 	ArtifactId  string
 	Version     string
 	Description string
+
+	// Catalog is the name of the CatalogSource this artifact was parsed from
+	Catalog string
 }
 
 type ArchetypeFilter struct {
@@ -46,6 +48,14 @@ type ArchetypeFilter struct {
 	GroupIdFilter    string
 	ArtifactIdFilter string
 	Version          string
+
+	// IncludeSnapshots shows SNAPSHOT versions in CreateSurvey's version picker. Defaults to
+	// false so users are only offered stable releases, matching what Maven users expect from a
+	// release-only catalog
+	IncludeSnapshots bool
+	// IncludePrereleases shows alpha/beta/milestone/rc versions in CreateSurvey's version
+	// picker. Defaults to false for the same reason as IncludeSnapshots
+	IncludePrereleases bool
 }
 
 type ArchetypeForm struct {
@@ -101,13 +111,33 @@ func (m *ArchetypeModel) Versions(groupId string, artifactId, filter string) []s
 					answer = append(answer, v)
 				}
 			}
-			// TODO use a version sorter?
-			sort.Sort(sort.Reverse(sort.StringSlice(answer)))
+			sort.Slice(answer, func(i, j int) bool {
+				return CompareVersions(answer[i], answer[j]) > 0
+			})
 		}
 	}
 	return answer
 }
 
+// filterStableVersions removes SNAPSHOT and pre-release versions from versions unless data
+// explicitly opts back into seeing them via IncludeSnapshots/IncludePrereleases
+func filterStableVersions(versions []string, data *ArchetypeFilter) []string {
+	if data.IncludeSnapshots && data.IncludePrereleases {
+		return versions
+	}
+	answer := []string{}
+	for _, v := range versions {
+		if !data.IncludeSnapshots && IsSnapshotVersion(v) {
+			continue
+		}
+		if !data.IncludePrereleases && IsPrereleaseVersion(v) {
+			continue
+		}
+		answer = append(answer, v)
+	}
+	return answer
+}
+
 func (m *ArchetypeModel) AddArtifact(a *ArtifactData) *ArtifactVersions {
 	groupId := a.GroupId
 	artifactId := a.ArtifactId
@@ -137,8 +167,11 @@ func (m *ArchetypeModel) AddArtifact(a *ArtifactData) *ArtifactVersions {
 		}
 		group.Artifacts[artifactId] = artifact
 	}
-	if artifact.Description == "" && description != "" {
+	// keep the longest available description, recording which catalog it came from, so that
+	// merging several catalogs favours whichever one documents the archetype best
+	if description != "" && len(description) > len(artifact.Description) {
 		artifact.Description = description
+		artifact.Catalog = a.Catalog
 	}
 	if util.StringArrayIndex(artifact.Versions, version) < 0 {
 		artifact.Versions = append(artifact.Versions, version)
@@ -146,71 +179,6 @@ func (m *ArchetypeModel) AddArtifact(a *ArtifactData) *ArtifactVersions {
 	return artifact
 }
 
-func LoadArchetypes(name string, archetypeCatalogURL string, cacheDir string) (*ArchetypeModel, error) {
-	loader := func() ([]byte, error) {
-		client := http.Client{}
-		req, err := http.NewRequest(http.MethodGet, archetypeCatalogURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Accept", "application/xml")
-
-		res, err := client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		return ioutil.ReadAll(res.Body)
-	}
-
-	cacheFileName := ""
-	if cacheDir != "" {
-		cacheFileName = filepath.Join(cacheDir, "archetype-catalog-"+name+".xml")
-	}
-	body, err := util.LoadCacheData(cacheFileName, loader)
-	if err != nil {
-		return nil, err
-	}
-
-	model := NewArchetypeModel()
-	decoder := xml.NewDecoder(bytes.NewReader(body))
-	artifact := ArtifactData{}
-	elementName := ""
-	for {
-		token, err := decoder.Token()
-		if err != nil {
-			break
-		}
-		switch t := token.(type) {
-		case xml.StartElement:
-			elmt := xml.StartElement(t)
-			elementName = elmt.Name.Local
-		case xml.EndElement:
-			elmt := xml.EndElement(t)
-			elementName = elmt.Name.Local
-			if elementName == "archetype" {
-				model.AddArtifact(&artifact)
-				artifact = ArtifactData{}
-			}
-		case xml.CharData:
-			bytes := xml.CharData(t)
-			text := strings.TrimSpace(string(bytes))
-			if text != "" {
-				switch elementName {
-				case "groupId":
-					artifact.GroupId += text
-				case "artifactId":
-					artifact.ArtifactId += text
-				case "version":
-					artifact.Version += text
-				case "description":
-					artifact.Description += text
-				}
-			}
-		}
-	}
-	return &model, nil
-}
-
 func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion bool, form *ArchetypeForm) error {
 	groupIds := data.GroupIds
 	if len(data.GroupIds) == 0 {
@@ -268,7 +236,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 	}
 
 	version := data.Version
-	versions := model.Versions(form.ArchetypeGroupId, form.ArchetypeArtifactId, version)
+	versions := filterStableVersions(model.Versions(form.ArchetypeGroupId, form.ArchetypeArtifactId, version), data)
 	if len(versions) == 0 {
 		return util.InvalidOption("version", version, model.Versions(form.ArchetypeGroupId, form.ArchetypeArtifactId, ""))
 	}
@@ -0,0 +1,135 @@
+package maven
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// CatalogSource is one archetype catalog to load and merge into an ArchetypeModel, for example
+// Maven Central, an internal Nexus catalog or the user's local
+// ~/.m2/repository/archetype-catalog.xml
+type CatalogSource struct {
+	Name string
+	URL  string
+}
+
+// LoadArchetypesFromCatalogs loads and merges catalogs into a single ArchetypeModel. Each
+// catalog's URL is first rewritten through any matching mirror found in the user's
+// ~/.m2/settings.xml, then fetched - unless offline is true, or the request fails, in which case
+// it falls back to whatever was last cached under cacheDir. Artifacts that appear in more than
+// one catalog are merged by groupId/artifactId: their versions are unioned and the longest
+// available description is kept, recording which catalog contributed it.
+func LoadArchetypesFromCatalogs(catalogs []CatalogSource, cacheDir string, offline bool) (*ArchetypeModel, error) {
+	mirrors, err := loadMavenMirrors(mavenSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+
+	model := NewArchetypeModel()
+	for _, catalog := range catalogs {
+		url := resolveMirrorURL(catalog.Name, catalog.URL, mirrors)
+		err := loadArchetypeCatalog(&model, catalog.Name, url, cacheDir, offline)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load archetype catalog %s: %s", catalog.Name, err)
+		}
+	}
+	return &model, nil
+}
+
+// LoadArchetypes loads a single archetype catalog. It is a thin wrapper over
+// LoadArchetypesFromCatalogs for the common case of a caller with only one catalog URL.
+func LoadArchetypes(name string, archetypeCatalogURL string, cacheDir string) (*ArchetypeModel, error) {
+	return LoadArchetypesFromCatalogs([]CatalogSource{{Name: name, URL: archetypeCatalogURL}}, cacheDir, false)
+}
+
+// loadArchetypeCatalog fetches (or, when offline, reads from cache) the catalog at
+// archetypeCatalogURL and merges its artifacts into model
+func loadArchetypeCatalog(model *ArchetypeModel, name string, archetypeCatalogURL string, cacheDir string, offline bool) error {
+	cacheFileName := ""
+	if cacheDir != "" {
+		cacheFileName = filepath.Join(cacheDir, "archetype-catalog-"+name+".xml")
+	}
+
+	var body []byte
+	var err error
+	if offline {
+		if cacheFileName == "" {
+			return fmt.Errorf("no cache directory configured, cannot load catalog %s while offline", name)
+		}
+		body, err = ioutil.ReadFile(cacheFileName)
+		if err != nil {
+			return err
+		}
+	} else {
+		loader := func() ([]byte, error) {
+			client := http.Client{}
+			req, err := http.NewRequest(http.MethodGet, archetypeCatalogURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Accept", "application/xml")
+
+			res, err := client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+			return ioutil.ReadAll(res.Body)
+		}
+		body, err = util.LoadCacheData(cacheFileName, loader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return parseArchetypeCatalog(model, name, body)
+}
+
+// parseArchetypeCatalog parses the archetype-catalog.xml document in body, tagging every
+// artifact it finds with catalogName before adding it to model
+func parseArchetypeCatalog(model *ArchetypeModel, catalogName string, body []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	artifact := ArtifactData{Catalog: catalogName}
+	elementName := ""
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			elmt := xml.StartElement(t)
+			elementName = elmt.Name.Local
+		case xml.EndElement:
+			elmt := xml.EndElement(t)
+			elementName = elmt.Name.Local
+			if elementName == "archetype" {
+				model.AddArtifact(&artifact)
+				artifact = ArtifactData{Catalog: catalogName}
+			}
+		case xml.CharData:
+			bytes := xml.CharData(t)
+			text := strings.TrimSpace(string(bytes))
+			if text != "" {
+				switch elementName {
+				case "groupId":
+					artifact.GroupId += text
+				case "artifactId":
+					artifact.ArtifactId += text
+				case "version":
+					artifact.Version += text
+				case "description":
+					artifact.Description += text
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package maven
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// mavenMirror is a single <mirror> entry parsed out of ~/.m2/settings.xml
+type mavenMirror struct {
+	ID       string `xml:"id"`
+	URL      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+type mavenSettings struct {
+	XMLName xml.Name      `xml:"settings"`
+	Mirrors []mavenMirror `xml:"mirrors>mirror"`
+}
+
+// mavenSettingsPath returns the location of the current user's ~/.m2/settings.xml
+func mavenSettingsPath() string {
+	u, err := user.Current()
+	if err != nil || u.HomeDir == "" {
+		return ""
+	}
+	return filepath.Join(u.HomeDir, ".m2", "settings.xml")
+}
+
+// loadMavenMirrors parses the <mirrors> section of the settings.xml at path. A missing
+// settings.xml is not an error - it just means there are no mirrors to apply - but a settings.xml
+// that exists and fails to parse is reported to the caller
+func loadMavenMirrors(path string) ([]mavenMirror, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	settings := mavenSettings{}
+	err = xml.Unmarshal(data, &settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings.Mirrors, nil
+}
+
+// resolveMirrorURL returns the URL of the first mirror whose mirrorOf pattern matches repoID,
+// or the original catalogURL unchanged if no mirror matches
+func resolveMirrorURL(repoID string, catalogURL string, mirrors []mavenMirror) string {
+	external := isExternalRepoURL(catalogURL)
+	for _, mirror := range mirrors {
+		if mirrorOfMatches(mirror.MirrorOf, repoID, external) {
+			return mirror.URL
+		}
+	}
+	return catalogURL
+}
+
+// isExternalRepoURL reports whether url points somewhere other than localhost or a file, which
+// is how Maven decides whether a repository matches the "external:*" mirrorOf pattern
+func isExternalRepoURL(url string) bool {
+	if strings.HasPrefix(url, "file:") {
+		return false
+	}
+	return !strings.Contains(url, "://localhost") && !strings.Contains(url, "://127.0.0.1")
+}
+
+// mirrorOfMatches implements Maven's <mirrorOf> glob syntax: a comma separated list of repository
+// IDs, "*" to match everything, "external:*" to match any non-local repository, and a "!id"
+// entry to exclude that repository id regardless of what else in the list matches it
+func mirrorOfMatches(mirrorOf string, repoID string, external bool) bool {
+	matched := false
+	for _, token := range strings.Split(mirrorOf, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		excluded := strings.HasPrefix(token, "!")
+		name := strings.TrimPrefix(token, "!")
+
+		var hit bool
+		switch name {
+		case "*":
+			hit = true
+		case "external:*":
+			hit = external
+		default:
+			hit = name == repoID
+		}
+
+		if excluded {
+			if hit {
+				return false
+			}
+			continue
+		}
+		if hit {
+			matched = true
+		}
+	}
+	return matched
+}
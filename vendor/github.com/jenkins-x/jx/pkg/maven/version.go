@@ -0,0 +1,139 @@
+package maven
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// qualifierRank orders the well known Maven version qualifiers from weakest to strongest, with
+// "" (a plain release, e.g. "1.0.0") ranked above every pre-release qualifier but below "sp"
+// (a service pack / patch release). Unrecognised qualifiers are treated as equivalent to a plain
+// release so an unusual qualifier doesn't accidentally sort before every known pre-release one
+var qualifierRank = map[string]int{
+	"alpha":     0,
+	"beta":      1,
+	"milestone": 2,
+	"m":         2,
+	"rc":        3,
+	"cr":        3,
+	"snapshot":  4,
+	"":          5,
+	"sp":        6,
+}
+
+const defaultQualifierRank = 5
+
+var versionTokenSplitter = regexp.MustCompile(`[.\-]`)
+
+// isNumericToken reports whether token consists only of digits
+func isNumericToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, r := range token {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// rankOf returns the qualifier rank of a non-numeric token, treating it case-insensitively
+func rankOf(token string) int {
+	rank, ok := qualifierRank[strings.ToLower(token)]
+	if !ok {
+		return defaultQualifierRank
+	}
+	return rank
+}
+
+// compareVersionTokens compares a single "." or "-" separated token of two versions, numeric
+// segments are compared numerically, non-numeric segments by their Maven qualifier rank, and a
+// numeric segment always outranks a qualifier (e.g. "1" > "rc")
+func compareVersionTokens(a, b string) int {
+	aIsNumeric := isNumericToken(a)
+	bIsNumeric := isNumericToken(b)
+
+	if aIsNumeric && bIsNumeric {
+		an, _ := strconv.Atoi(a)
+		bn, _ := strconv.Atoi(b)
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		default:
+			return 0
+		}
+	}
+	if aIsNumeric != bIsNumeric {
+		if aIsNumeric {
+			return 1
+		}
+		return -1
+	}
+
+	ar, br := rankOf(a), rankOf(b)
+	switch {
+	case ar < br:
+		return -1
+	case ar > br:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// CompareVersions compares two Maven style version strings, splitting on "." and "-" and
+// comparing each segment in turn. Numeric segments compare numerically; non-numeric segments
+// compare using the Maven qualifier ordering (alpha < beta < milestone < rc < snapshot < "" < sp)
+// so that, for example, "1.0.0-SNAPSHOT" < "1.0.0" < "1.0.1" < "1.2.0" < "1.10.0". It returns -1
+// if a < b, 0 if they're equal and 1 if a > b
+func CompareVersions(a, b string) int {
+	aTokens := versionTokenSplitter.Split(a, -1)
+	bTokens := versionTokenSplitter.Split(b, -1)
+
+	max := len(aTokens)
+	if len(bTokens) > max {
+		max = len(bTokens)
+	}
+
+	for i := 0; i < max; i++ {
+		aToken := ""
+		if i < len(aTokens) {
+			aToken = aTokens[i]
+		}
+		bToken := ""
+		if i < len(bTokens) {
+			bToken = bTokens[i]
+		}
+		if aToken == bToken {
+			continue
+		}
+		if c := compareVersionTokens(aToken, bToken); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// IsSnapshotVersion reports whether version is a Maven SNAPSHOT version
+func IsSnapshotVersion(version string) bool {
+	return strings.HasSuffix(strings.ToLower(version), "-snapshot")
+}
+
+// prereleaseQualifiers are the qualifiers that mark a version as a pre-release rather than a
+// stable, generally available release
+var prereleaseQualifiers = []string{"alpha", "beta", "milestone", "m", "rc", "cr"}
+
+// IsPrereleaseVersion reports whether version carries an alpha/beta/milestone/rc qualifier
+func IsPrereleaseVersion(version string) bool {
+	lower := strings.ToLower(version)
+	for _, q := range prereleaseQualifiers {
+		if strings.Contains(lower, "-"+q) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package templates
+
+// builtinPacks are the template packs shipped inside the jx binary. Projects can override any
+// of these, or add new ones, by dropping a same-named directory under $JX_HOME/templates
+var builtinPacks = map[string]Pack{
+	"maven": {
+		Name: "maven",
+		Files: map[string]string{
+			"Jenkinsfile":        mavenJenkinsfile,
+			"Dockerfile.release": mavenDockerfile,
+		},
+	},
+	"go": {
+		Name: "go",
+		Files: map[string]string{
+			"Jenkinsfile":        goJenkinsfile,
+			"Dockerfile.release": goDockerfile,
+		},
+	},
+	"node": {
+		Name: "node",
+		Files: map[string]string{
+			"Jenkinsfile":        nodeJenkinsfile,
+			"Dockerfile.release": nodeDockerfile,
+		},
+	},
+}
+
+// TODO replace with the jx-pipelines-plugin version when its available
+const mavenJenkinsfile = `
+pipeline {
+    agent {
+      label "jenkins-maven"
+    }
+
+    environment {
+      ORG 		        = '{{.Organisation}}'
+      APP_NAME          = '{{.AppName}}'
+      GIT_CREDS         = credentials('jenkins-x-git')
+      CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')
+
+      GIT_USERNAME      = "$GIT_CREDS_USR"
+      GIT_API_TOKEN     = "$GIT_CREDS_PSW"
+      JOB_NAME          = "$JOB_NAME"
+      BUILD_NUMBER      = "$BUILD_NUMBER"
+    }
+
+    stages {
+      stage('CI Build and push snapshpt') {
+        when {
+          branch '{{.BranchPatterns}}'
+        }
+        environment {
+          PREVIEW_VERSION = "0.0.0-SNAPSHOT-$BRANCH_NAME-$BUILD_NUMBER"
+          PREVIEW_NAMESPACE = "{{.PreviewNamespacePattern}}".toLowerCase()
+          HELM_RELEASE = "$PREVIEW_NAMESPACE".toLowerCase()
+        }
+        steps {
+          container('maven') {
+            sh "mvn versions:set -DnewVersion=$PREVIEW_VERSION"
+            sh "mvn install"
+            sh "docker build -f Dockerfile.release -t {{.DockerRegistry}}/$ORG/$APP_NAME:$PREVIEW_VERSION ."
+            sh "docker push {{.DockerRegistry}}/$ORG/$APP_NAME:$PREVIEW_VERSION"
+          }
+        }
+      }
+
+      stage('Build Release') {
+        when {
+          branch 'master'
+        }
+        steps {
+          container('maven') {
+            sh "git checkout master"
+            sh "git config --global credential.helper store"
+            sh "echo \$(jx-release-version) > VERSION"
+            sh "mvn versions:set -DnewVersion=\$(cat VERSION)"
+          }
+
+          dir ('./charts/{{.AppName}}') {
+            container('maven') {
+              sh "make tag"
+            }
+          }
+
+          container('maven') {
+            sh 'mvn clean deploy'
+            sh "docker build -f Dockerfile.release -t {{.DockerRegistry}}/$ORG/$APP_NAME:\$(cat VERSION) ."
+            sh "docker push {{.DockerRegistry}}/$ORG/$APP_NAME:\$(cat VERSION)"
+            sh 'jx step changelog --version \$(cat VERSION)'
+          }
+        }
+      }
+
+      stage('Promote to Environments') {
+        environment {
+          GIT_USERNAME = "$GIT_CREDS_USR"
+          GIT_API_TOKEN = "$GIT_CREDS_PSW"
+        }
+        when {
+          branch 'master'
+        }
+        steps {
+          dir ('./charts/{{.AppName}}') {
+            container('maven') {
+              sh 'make release'
+              sh 'jx promote -b --all-auto --timeout 1h --version \$(cat ../../VERSION)'
+            }
+          }
+        }
+      }
+    }
+  }
+`
+
+const mavenDockerfile = `
+FROM openjdk:8-jdk-alpine
+ENV PORT 8080
+EXPOSE 8080
+COPY target/*.jar /opt/app.jar
+WORKDIR /opt
+CMD ["java", "-jar", "app.jar"]
+`
+
+const goJenkinsfile = `
+pipeline {
+    agent {
+      label "jenkins-go"
+    }
+
+    environment {
+      ORG 		        = '{{.Organisation}}'
+      APP_NAME          = '{{.AppName}}'
+      GIT_CREDS         = credentials('jenkins-x-git')
+      CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')
+    }
+
+    stages {
+      stage('CI Build and push snapshpt') {
+        when {
+          branch '{{.BranchPatterns}}'
+        }
+        environment {
+          PREVIEW_VERSION = "0.0.0-SNAPSHOT-$BRANCH_NAME-$BUILD_NUMBER"
+        }
+        steps {
+          container('go') {
+            sh "make build"
+            sh "docker build -f Dockerfile.release -t {{.DockerRegistry}}/$ORG/$APP_NAME:$PREVIEW_VERSION ."
+            sh "docker push {{.DockerRegistry}}/$ORG/$APP_NAME:$PREVIEW_VERSION"
+          }
+        }
+      }
+
+      stage('Build Release') {
+        when {
+          branch 'master'
+        }
+        steps {
+          container('go') {
+            sh "echo \$(jx-release-version) > VERSION"
+            sh "make build"
+            sh "docker build -f Dockerfile.release -t {{.DockerRegistry}}/$ORG/$APP_NAME:\$(cat VERSION) ."
+            sh "docker push {{.DockerRegistry}}/$ORG/$APP_NAME:\$(cat VERSION)"
+          }
+
+          dir ('./charts/{{.AppName}}') {
+            container('go') {
+              sh "make tag"
+              sh 'make release'
+              sh 'jx promote -b --all-auto --timeout 1h --version \$(cat ../../VERSION)'
+            }
+          }
+        }
+      }
+    }
+  }
+`
+
+const goDockerfile = `
+FROM scratch
+ENV PORT 8080
+EXPOSE 8080
+COPY bin/app /app
+ENTRYPOINT ["/app"]
+`
+
+const nodeJenkinsfile = `
+pipeline {
+    agent {
+      label "jenkins-nodejs"
+    }
+
+    environment {
+      ORG 		        = '{{.Organisation}}'
+      APP_NAME          = '{{.AppName}}'
+      GIT_CREDS         = credentials('jenkins-x-git')
+      CHARTMUSEUM_CREDS = credentials('jenkins-x-chartmuseum')
+    }
+
+    stages {
+      stage('CI Build and push snapshpt') {
+        when {
+          branch '{{.BranchPatterns}}'
+        }
+        environment {
+          PREVIEW_VERSION = "0.0.0-SNAPSHOT-$BRANCH_NAME-$BUILD_NUMBER"
+        }
+        steps {
+          container('nodejs') {
+            sh "npm install"
+            sh "npm test"
+            sh "docker build -f Dockerfile.release -t {{.DockerRegistry}}/$ORG/$APP_NAME:$PREVIEW_VERSION ."
+            sh "docker push {{.DockerRegistry}}/$ORG/$APP_NAME:$PREVIEW_VERSION"
+          }
+        }
+      }
+
+      stage('Build Release') {
+        when {
+          branch 'master'
+        }
+        steps {
+          container('nodejs') {
+            sh "echo \$(jx-release-version) > VERSION"
+            sh "npm install"
+            sh "docker build -f Dockerfile.release -t {{.DockerRegistry}}/$ORG/$APP_NAME:\$(cat VERSION) ."
+            sh "docker push {{.DockerRegistry}}/$ORG/$APP_NAME:\$(cat VERSION)"
+          }
+
+          dir ('./charts/{{.AppName}}') {
+            container('nodejs') {
+              sh "make tag"
+              sh 'make release'
+              sh 'jx promote -b --all-auto --timeout 1h --version \$(cat ../../VERSION)'
+            }
+          }
+        }
+      }
+    }
+  }
+`
+
+const nodeDockerfile = `
+FROM node:8-alpine
+ENV PORT 8080
+EXPOSE 8080
+COPY . /opt/app
+WORKDIR /opt/app
+CMD ["npm", "start"]
+`
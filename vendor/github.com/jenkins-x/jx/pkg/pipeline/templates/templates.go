@@ -0,0 +1,106 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// DefaultPackName is the template pack used when a project's language can't be detected
+const DefaultPackName = "maven"
+
+// Variables is the bag of values a template pack's files are rendered against
+type Variables struct {
+	AppName                 string
+	Organisation            string
+	DockerRegistry          string
+	ChartsRepo              string
+	PreviewNamespacePattern string
+	BranchPatterns          string
+
+	// Set holds arbitrary user supplied `--set key=value` overrides
+	Set map[string]string
+}
+
+// Pack is a named collection of pipeline files (Jenkinsfile, Dockerfile.release, chart
+// skeletons, ...) rendered via text/template against a Variables bag
+type Pack struct {
+	Name string
+
+	// Files maps a file's relative path inside the project (e.g. "Jenkinsfile") to its
+	// unrendered template source
+	Files map[string]string
+}
+
+// LoadPack finds the named template pack, preferring a user override in
+// $JX_HOME/templates/<name> over the bundled default of the same name
+func LoadPack(home string, name string) (*Pack, error) {
+	if name == "" {
+		name = DefaultPackName
+	}
+
+	if home != "" {
+		dir := filepath.Join(home, "templates", name)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return loadPackFromDir(name, dir)
+		}
+	}
+
+	pack, ok := builtinPacks[name]
+	if !ok {
+		return nil, fmt.Errorf("no template pack found called %s", name)
+	}
+	return &pack, nil
+}
+
+func loadPackFromDir(name string, dir string) (*Pack, error) {
+	pack := &Pack{
+		Name:  name,
+		Files: map[string]string{},
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		pack.Files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template pack %s from %s: %s", name, dir, err)
+	}
+	return pack, nil
+}
+
+// Render executes the named file's template against the given variables
+func (p *Pack) Render(file string, vars Variables) ([]byte, error) {
+	source, ok := p.Files[file]
+	if !ok {
+		return nil, fmt.Errorf("template pack %s does not provide a %s", p.Name, file)
+	}
+
+	t, err := template.New(file).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s in template pack %s: %s", file, p.Name, err)
+	}
+
+	var buffer bytes.Buffer
+	err = t.Execute(&buffer, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s from template pack %s: %s", file, p.Name, err)
+	}
+	return buffer.Bytes(), nil
+}
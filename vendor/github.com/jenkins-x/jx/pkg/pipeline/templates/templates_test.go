@@ -0,0 +1,36 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderBuiltinPacks(t *testing.T) {
+	vars := Variables{
+		AppName:                 "myapp",
+		Organisation:            "jenkinsx",
+		DockerRegistry:          "dockerregistry",
+		PreviewNamespacePattern: "myapp-$BRANCH_NAME",
+		BranchPatterns:          "PR-*",
+	}
+
+	for _, name := range []string{"maven", "go", "node"} {
+		pack, err := LoadPack("", name)
+		assert.NoError(t, err, "LoadPack(%s)", name)
+		assert.Equal(t, name, pack.Name)
+
+		jenkinsfile, err := pack.Render("Jenkinsfile", vars)
+		assert.NoError(t, err, "Render(Jenkinsfile) for pack %s", name)
+		assert.Contains(t, string(jenkinsfile), "myapp", "rendered Jenkinsfile for pack %s should contain the app name", name)
+
+		dockerfile, err := pack.Render("Dockerfile.release", vars)
+		assert.NoError(t, err, "Render(Dockerfile.release) for pack %s", name)
+		assert.NotEmpty(t, dockerfile)
+	}
+}
+
+func TestLoadPackUnknown(t *testing.T) {
+	_, err := LoadPack("", "doesnotexist")
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package testkube
 
 import (
+	"strconv"
+
 	"github.com/jenkins-x/jx/pkg/kube"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,3 +29,71 @@ func CreateTestPipelineGitSecret(gitServiceKind string, name string, gitUrl stri
 		},
 	}
 }
+
+// CreateTestPipelineGitSSHSecret creates a test git pipeline credential secret authenticating
+// over SSH with a private key and optional passphrase
+func CreateTestPipelineGitSSHSecret(name string, gitUrl string, username string, privateKeyPEM []byte, passphrase string) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kube.ToValidName(name),
+			Annotations: map[string]string{
+				kube.AnnotationURL:  gitUrl,
+				kube.AnnotationName: name,
+			},
+			Labels: map[string]string{
+				kube.LabelKind:            kube.ValueKindGit,
+				kube.LabelCredentialsType: kube.ValueCredentialTypeSSHPrivateKey,
+			},
+		},
+		Data: map[string][]byte{
+			kube.SecretDataUsername:      []byte(username),
+			kube.SecretDataSSHPrivateKey: privateKeyPEM,
+			kube.SecretDataSSHPassphrase: []byte(passphrase),
+		},
+	}
+}
+
+// CreateTestPipelineGitTokenSecret creates a test git pipeline credential secret authenticating
+// with a personal access token in place of a password
+func CreateTestPipelineGitTokenSecret(name string, gitUrl string, username string, token string) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kube.ToValidName(name),
+			Annotations: map[string]string{
+				kube.AnnotationURL:  gitUrl,
+				kube.AnnotationName: name,
+			},
+			Labels: map[string]string{
+				kube.LabelKind:            kube.ValueKindGit,
+				kube.LabelCredentialsType: kube.ValueCredentialTypeToken,
+			},
+		},
+		Data: map[string][]byte{
+			kube.SecretDataUsername: []byte(username),
+			kube.SecretDataToken:    []byte(token),
+		},
+	}
+}
+
+// CreateTestPipelineGitHubAppSecret creates a test git pipeline credential secret authenticating
+// as a GitHub App installation rather than as a user
+func CreateTestPipelineGitHubAppSecret(name string, gitUrl string, appID int64, installationID int64, privateKeyPEM []byte) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kube.ToValidName(name),
+			Annotations: map[string]string{
+				kube.AnnotationURL:  gitUrl,
+				kube.AnnotationName: name,
+			},
+			Labels: map[string]string{
+				kube.LabelKind:            kube.ValueKindGit,
+				kube.LabelCredentialsType: kube.ValueCredentialTypeGitHubApp,
+			},
+		},
+		Data: map[string][]byte{
+			kube.SecretDataGitHubAppID:          []byte(strconv.FormatInt(appID, 10)),
+			kube.SecretDataGitHubInstallationID: []byte(strconv.FormatInt(installationID, 10)),
+			kube.SecretDataSSHPrivateKey:        privateKeyPEM,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package versionstream
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"gopkg.in/yaml.v2"
+)
+
+// PackagesDir is the directory inside a version stream checkout that holds one YAML file per
+// pinned package
+const PackagesDir = "packages"
+
+// PackageVersion is the pinned version, checksum and download URL for a single external binary
+// dependency (kubectl, helm, kops, ...), as recorded in <stream>/packages/<name>.yml
+type PackageVersion struct {
+	Version string `yaml:"version"`
+	SHA256  string `yaml:"sha256"`
+	URL     string `yaml:"url"`
+}
+
+// ResolvePackage loads the pinned PackageVersion for name from the version stream checked out at
+// dir, returning nil (with no error) if the stream has no entry for this package yet so callers
+// can fall back to resolving the latest upstream release
+func ResolvePackage(dir string, name string) (*PackageVersion, error) {
+	path := filepath.Join(dir, PackagesDir, name+".yml")
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read version stream package file %s: %s", path, err)
+	}
+	version := &PackageVersion{}
+	err = yaml.Unmarshal(data, version)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse version stream package file %s: %s", path, err)
+	}
+	return version, nil
+}
+
+// CloneOrPullVersionsRepo ensures dir contains an up to date checkout of ref from the version
+// stream repo at url, cloning it if dir doesn't exist yet or pulling it otherwise, and returns
+// dir for convenience so callers can chain it straight into ResolvePackage
+func CloneOrPullVersionsRepo(url string, ref string, dir string) (string, error) {
+	if ref == "" {
+		ref = "master"
+	}
+	checkoutRef := ref
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		err = gits.GitCmd(dir, "fetch", "origin", ref)
+		if err != nil {
+			return dir, fmt.Errorf("Failed to fetch %s in version stream %s: %s", ref, dir, err)
+		}
+		checkoutRef = "FETCH_HEAD"
+	} else {
+		err = os.MkdirAll(filepath.Dir(dir), 0760)
+		if err != nil {
+			return dir, err
+		}
+		err = gits.GitClone(url, dir)
+		if err != nil {
+			return dir, fmt.Errorf("Failed to clone version stream %s to %s: %s", url, dir, err)
+		}
+	}
+	err := gits.GitCmd(dir, "checkout", checkoutRef)
+	if err != nil {
+		return dir, fmt.Errorf("Failed to checkout %s in version stream %s: %s", ref, dir, err)
+	}
+	return dir, nil
+}